@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const GoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+var (
+	ErrUnknownKey      = errors.New("unknown signing key")
+	ErrInvalidIssuer   = errors.New("invalid token issuer")
+	ErrInvalidAudience = errors.New("invalid token audience")
+)
+
+// GoogleClaims are the fields of a Google ID token that we rely on.
+type GoogleClaims struct {
+	Subject       string `json:"sub"`
+	Issuer        string `json:"iss"`
+	Audience      string `json:"aud"`
+	ExpiresAt     int64  `json:"exp"`
+	IssuedAt      int64  `json:"iat"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+}
+
+// KeySource resolves the RSA public key for a JWKS key ID.
+type KeySource interface {
+	PublicKey(kid string) (*rsa.PublicKey, error)
+}
+
+// StaticKeySource is a fixed set of keys, used when keys are injected locally
+// instead of being fetched from Google.
+type StaticKeySource map[string]*rsa.PublicKey
+
+func (s StaticKeySource) PublicKey(kid string) (*rsa.PublicKey, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// LoadJWKSFile reads a JWKS document from disk into a StaticKeySource.
+func LoadJWKSFile(path string) (StaticKeySource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// ParseJWKS decodes the RSA keys of a JWKS document.
+func ParseJWKS(data []byte) (StaticKeySource, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	keys := StaticKeySource{}
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for key %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for key %s: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// RemoteKeySource fetches a JWKS document over HTTP and caches it until the
+// response's max-age runs out.
+type RemoteKeySource struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    StaticKeySource
+	expires time.Time
+}
+
+func NewRemoteKeySource(url string) *RemoteKeySource {
+	return &RemoteKeySource{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (r *RemoteKeySource) PublicKey(kid string) (*rsa.PublicKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.keys == nil || time.Now().After(r.expires) {
+		if err := r.refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := r.keys.PublicKey(kid)
+	if err == ErrUnknownKey {
+		// Google may have rotated keys before our cache expired
+		if err := r.refresh(); err != nil {
+			return nil, err
+		}
+		return r.keys.PublicKey(kid)
+	}
+	return key, err
+}
+
+func (r *RemoteKeySource) refresh() error {
+	resp, err := r.client.Get(r.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+	keys, err := ParseJWKS(body)
+	if err != nil {
+		return err
+	}
+
+	r.keys = keys
+	r.expires = time.Now().Add(cacheMaxAge(resp.Header.Get("Cache-Control")))
+	return nil
+}
+
+func cacheMaxAge(header string) time.Duration {
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.HasPrefix(directive, "max-age=") {
+			var seconds int
+			if _, err := fmt.Sscanf(directive, "max-age=%d", &seconds); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return time.Hour
+}
+
+// ClientIDs trims each client ID and drops the blank ones.
+func ClientIDs(ids []string) []string {
+	var clean []string
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			clean = append(clean, id)
+		}
+	}
+	return clean
+}
+
+// GoogleVerifier validates Google-issued ID tokens for our OAuth client IDs.
+type GoogleVerifier struct {
+	audiences []string
+	keys      KeySource
+	now       func() time.Time
+}
+
+// NewGoogleVerifier accepts tokens issued for any of the given client IDs.
+// Surrounding whitespace is trimmed and blank IDs are ignored, so an unset
+// GOOGLE_CLIENT_IDS can't end up matching tokens without an audience.
+func NewGoogleVerifier(audiences []string, keys KeySource) *GoogleVerifier {
+	return &GoogleVerifier{
+		audiences: ClientIDs(audiences),
+		keys:      keys,
+		now:       time.Now,
+	}
+}
+
+func (v *GoogleVerifier) Verify(idToken string) (*GoogleClaims, error) {
+	token, err := parseToken(idToken)
+	if err != nil {
+		return nil, err
+	}
+	if token.header.Alg != "RS256" {
+		return nil, ErrInvalidSignature
+	}
+
+	key, err := v.keys.PublicKey(token.header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(token.signingInput))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], token.signature); err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims GoogleClaims
+	if err := json.Unmarshal(token.payload, &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if claims.Issuer != "accounts.google.com" && claims.Issuer != "https://accounts.google.com" {
+		return nil, ErrInvalidIssuer
+	}
+	if !v.validAudience(claims.Audience) {
+		return nil, ErrInvalidAudience
+	}
+	if v.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	if claims.Subject == "" {
+		return nil, ErrMalformedToken
+	}
+
+	return &claims, nil
+}
+
+func (v *GoogleVerifier) validAudience(aud string) bool {
+	if aud == "" {
+		return false
+	}
+	for _, a := range v.audiences {
+		if a == aud {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func signGoogleToken(t *testing.T, key *rsa.PrivateKey, header jwtHeader, claims GoogleClaims) string {
+	t.Helper()
+	encodedHeader, err := encodeSegment(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedClaims, err := encodeSegment(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingInput := encodedHeader + "." + encodedClaims
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestGoogleVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	verifier := NewGoogleVerifier([]string{"client-id"}, StaticKeySource{"key-1": &key.PublicKey})
+	verifier.now = func() time.Time { return now }
+
+	valid := GoogleClaims{
+		Subject:   "google-user",
+		Issuer:    "https://accounts.google.com",
+		Audience:  "client-id",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+		Email:     "user@example.com",
+	}
+	header := jwtHeader{Alg: "RS256", Kid: "key-1", Typ: "JWT"}
+
+	tests := []struct {
+		name    string
+		key     *rsa.PrivateKey
+		header  jwtHeader
+		modify  func(*GoogleClaims)
+		wantErr error
+	}{
+		{name: "valid", key: key, header: header},
+		{name: "short issuer", key: key, header: header, modify: func(c *GoogleClaims) { c.Issuer = "accounts.google.com" }},
+		{name: "other issuer", key: key, header: header, modify: func(c *GoogleClaims) { c.Issuer = "https://example.com" }, wantErr: ErrInvalidIssuer},
+		{name: "other audience", key: key, header: header, modify: func(c *GoogleClaims) { c.Audience = "someone-else" }, wantErr: ErrInvalidAudience},
+		{name: "expired", key: key, header: header, modify: func(c *GoogleClaims) { c.ExpiresAt = now.Unix() }, wantErr: ErrTokenExpired},
+		{name: "missing subject", key: key, header: header, modify: func(c *GoogleClaims) { c.Subject = "" }, wantErr: ErrMalformedToken},
+		{name: "unknown key", key: key, header: jwtHeader{Alg: "RS256", Kid: "key-2"}, wantErr: ErrUnknownKey},
+		{name: "wrong signer", key: otherKey, header: header, wantErr: ErrInvalidSignature},
+		{name: "wrong algorithm", key: key, header: jwtHeader{Alg: "HS256", Kid: "key-1"}, wantErr: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid
+			if tt.modify != nil {
+				tt.modify(&claims)
+			}
+			got, err := verifier.Verify(signGoogleToken(t, tt.key, tt.header, claims))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Subject != claims.Subject {
+				t.Errorf("Subject = %q, want %q", got.Subject, claims.Subject)
+			}
+		})
+	}
+
+	if _, err := verifier.Verify("not-a-token"); !errors.Is(err, ErrMalformedToken) {
+		t.Errorf("Verify(malformed) error = %v, want %v", err, ErrMalformedToken)
+	}
+}
+
+func TestGoogleVerifierIgnoresBlankClientIDs(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	claims := GoogleClaims{
+		Subject:   "google-user",
+		Issuer:    "https://accounts.google.com",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	}
+	token := signGoogleToken(t, key, jwtHeader{Alg: "RS256", Kid: "key-1"}, claims)
+
+	// What strings.Split makes of an unset or sloppy GOOGLE_CLIENT_IDS
+	for _, ids := range [][]string{{""}, {" client-id ", ""}} {
+		verifier := NewGoogleVerifier(ids, StaticKeySource{"key-1": &key.PublicKey})
+		verifier.now = func() time.Time { return now }
+		if _, err := verifier.Verify(token); !errors.Is(err, ErrInvalidAudience) {
+			t.Errorf("client IDs %q: empty audience error = %v, want %v", ids, err, ErrInvalidAudience)
+		}
+	}
+
+	if got := ClientIDs([]string{" a ", "", "  ", "b"}); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("ClientIDs = %q, want [a b]", got)
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"key-1","n":%q,"e":%q},{"kty":"EC","kid":"key-2"}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+
+	keys, err := ParseJWKS([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := keys.PublicKey("key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&key.PublicKey) {
+		t.Error("parsed key does not match")
+	}
+	if _, err := keys.PublicKey("key-2"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("non-RSA key error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestAccessToken(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	manager := NewTokenManager([]byte("secret"))
+	manager.now = func() time.Time { return now }
+
+	accountID, sessionID := uuid.New(), uuid.New()
+	token, expiresAt, err := manager.IssueAccessToken(accountID, sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(DefaultAccessTokenTTL); !expiresAt.Equal(want) {
+		t.Errorf("expiresAt = %v, want %v", expiresAt, want)
+	}
+
+	claims, err := manager.ParseAccessToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.AccountID != accountID || claims.SessionID != sessionID {
+		t.Errorf("claims = %+v, want account %s and session %s", claims, accountID, sessionID)
+	}
+
+	other := NewTokenManager([]byte("other-secret"))
+	other.now = manager.now
+	if _, err := other.ParseAccessToken(token); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("other secret error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	manager.now = func() time.Time { return expiresAt }
+	if _, err := manager.ParseAccessToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("expired error = %v, want %v", err, ErrTokenExpired)
+	}
+}
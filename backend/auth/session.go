@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// AccessClaims is the payload of the short-lived session tokens we issue.
+type AccessClaims struct {
+	AccountID uuid.UUID `json:"sub"`
+	SessionID uuid.UUID `json:"sid"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+// TokenManager signs and verifies session access tokens with HMAC-SHA256.
+type TokenManager struct {
+	secret          []byte
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	now             func() time.Time
+}
+
+func NewTokenManager(secret []byte) *TokenManager {
+	return &TokenManager{
+		secret:          secret,
+		AccessTokenTTL:  DefaultAccessTokenTTL,
+		RefreshTokenTTL: DefaultRefreshTokenTTL,
+		now:             time.Now,
+	}
+}
+
+func (m *TokenManager) IssueAccessToken(accountID uuid.UUID, sessionID uuid.UUID) (string, time.Time, error) {
+	now := m.now()
+	expiresAt := now.Add(m.AccessTokenTTL)
+
+	header, err := encodeSegment(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	payload, err := encodeSegment(AccessClaims{
+		AccountID: accountID,
+		SessionID: sessionID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	signingInput := header + "." + payload
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(m.sign(signingInput)), expiresAt, nil
+}
+
+func (m *TokenManager) ParseAccessToken(accessToken string) (*AccessClaims, error) {
+	token, err := parseToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if token.header.Alg != "HS256" {
+		return nil, ErrInvalidSignature
+	}
+	if !hmac.Equal(token.signature, m.sign(token.signingInput)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims AccessClaims
+	if err := json.Unmarshal(token.payload, &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if m.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+func (m *TokenManager) sign(input string) []byte {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
+
+// NewOpaqueToken returns a random URL-safe token, used for refresh tokens.
+func NewOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken returns the hex SHA-256 of a token so it can be stored at rest.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// PersonalAccessTokenPrefix marks tokens that are looked up in the database
+// rather than verified as signed session tokens.
+const PersonalAccessTokenPrefix = "csp_"
+
+func NewPersonalAccessToken() (string, error) {
+	token, err := NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return PersonalAccessTokenPrefix + token, nil
+}
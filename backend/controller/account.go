@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *Controller) UpdateAccount(ctx *gin.Context) {
+	var body models.UpdateAccountRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := c.service.UpdateAccountProfile(currentAccount(ctx).ID, body)
+	if err != nil {
+		if err == service.ErrInvalidName || err == service.ErrInvalidTimezone {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// DeleteAccount anonymizes the caller. Pass ?settleBalances=true to mark their
+// unsettled splits as settled instead of getting a 409 listing them.
+func (c *Controller) DeleteAccount(ctx *gin.Context) {
+	settleBalances := ctx.Query("settleBalances") == "true"
+
+	response, err := c.service.DeleteAccount(currentAccount(ctx).ID, settleBalances)
+	if err != nil {
+		if err == service.ErrOutstandingBalances {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":             err.Error(),
+				"outstandingSplits": response.OutstandingSplits,
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *Controller) ExportAccountData(ctx *gin.Context) {
+	export, err := c.service.ExportAccountData(currentAccount(ctx).ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename=chore-share-export.json")
+	ctx.JSON(http.StatusOK, export)
+}
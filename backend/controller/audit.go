@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetAuditLog lists the household's audit log. It accepts actorId, action,
+// entityType, entityId, since and until (RFC 3339) filters and limit/offset
+// pagination.
+func (c *Controller) GetAuditLog(ctx *gin.Context) {
+	filter := service.AuditLogFilter{
+		Action:     models.AuditAction(ctx.Query("action")),
+		EntityType: models.AuditEntityType(ctx.Query("entityType")),
+	}
+
+	for param, dest := range map[string]**uuid.UUID{
+		"actorId":  &filter.ActorID,
+		"entityId": &filter.EntityID,
+	} {
+		if value := ctx.Query(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return
+			}
+			*dest = &id
+		}
+	}
+
+	for param, dest := range map[string]**time.Time{
+		"since": &filter.Since,
+		"until": &filter.Until,
+	} {
+		if value := ctx.Query(param); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + ", use RFC 3339"})
+				return
+			}
+			*dest = &t
+		}
+	}
+
+	for param, dest := range map[string]*int{
+		"limit":  &filter.Limit,
+		"offset": &filter.Offset,
+	} {
+		if value := ctx.Query(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return
+			}
+			*dest = n
+		}
+	}
+
+	page, err := c.service.GetAuditLog(currentMembership(ctx).HouseholdID, filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
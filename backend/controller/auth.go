@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"chore-share/auth"
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	accountContextKey             = "account"
+	sessionContextKey             = "session"
+	personalAccessTokenContextKey = "personalAccessToken"
+)
+
+func (c *Controller) SignInWithGoogle(ctx *gin.Context) {
+	var body models.GoogleSignInRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := c.google.Verify(body.IDToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Google ID token"})
+		return
+	}
+
+	// Only verified emails are stored since invites can be addressed to them
+	email := ""
+	if claims.EmailVerified {
+		email = claims.Email
+	}
+
+	account, err := c.service.GetAccountByGoogleId(claims.Subject)
+	if err == gorm.ErrRecordNotFound {
+		account, err = c.service.CreateAccount(&models.Account{
+			GoogleId: claims.Subject,
+			Name:     claims.Name,
+			Email:    email,
+		})
+	} else if err == nil && email != "" && account.Email != email {
+		err = c.service.UpdateAccountEmail(account.ID, email)
+		account.Email = email
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	refreshToken, err := auth.NewOpaqueToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := c.service.CreateSession(account.ID, auth.HashToken(refreshToken), time.Now().Add(c.tokens.RefreshTokenTTL))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.respondWithSession(ctx, account, session, refreshToken)
+}
+
+func (c *Controller) RefreshSession(ctx *gin.Context) {
+	var body models.RefreshSessionRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	refreshToken, err := auth.NewOpaqueToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := c.service.RotateSession(auth.HashToken(body.RefreshToken), auth.HashToken(refreshToken), time.Now().Add(c.tokens.RefreshTokenTTL))
+	if err != nil {
+		if err == service.ErrInvalidSession {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	account := models.AccountResponse{
+		ID:        session.Account.ID,
+		Name:      session.Account.Name,
+		GoogleId:  session.Account.GoogleId,
+		Email:     session.Account.Email,
+		AvatarURL: session.Account.AvatarURL,
+		Timezone:  session.Account.Timezone,
+	}
+	c.respondWithSession(ctx, account, session, refreshToken)
+}
+
+func (c *Controller) SignOut(ctx *gin.Context) {
+	if err := c.service.RevokeSession(ctx.MustGet(sessionContextKey).(models.Session).ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Signed out successfully"})
+}
+
+func (c *Controller) respondWithSession(ctx *gin.Context, account models.AccountResponse, session models.Session, refreshToken string) {
+	accessToken, expiresAt, err := c.tokens.IssueAccessToken(account.ID, session.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.SessionResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: expiresAt,
+		RefreshToken:         refreshToken,
+		Account:              account,
+	})
+}
+
+// Authenticate resolves the bearer token, either a session token or a personal
+// access token, to an account and stores it on the context. Routes that still
+// carry an :accountId must match that account.
+func (c *Controller) Authenticate(ctx *gin.Context) {
+	header := ctx.GetHeader("Authorization")
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || token == "" {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing bearer token"})
+		return
+	}
+
+	var account models.Account
+	if strings.HasPrefix(token, auth.PersonalAccessTokenPrefix) {
+		accessToken, ok := c.authenticatePersonalAccessToken(ctx, token)
+		if !ok {
+			return
+		}
+		account = accessToken.Account
+		ctx.Set(personalAccessTokenContextKey, accessToken)
+	} else {
+		session, ok := c.authenticateSession(ctx, token)
+		if !ok {
+			return
+		}
+		account = session.Account
+		ctx.Set(sessionContextKey, session)
+	}
+
+	if accountParam := ctx.Param("accountId"); accountParam != "" {
+		accountId, err := uuid.Parse(accountParam)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if accountId != account.ID {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Cannot act on behalf of another account"})
+			return
+		}
+	}
+
+	ctx.Set(accountContextKey, account)
+	ctx.Next()
+}
+
+func (c *Controller) authenticateSession(ctx *gin.Context, token string) (models.Session, bool) {
+	claims, err := c.tokens.ParseAccessToken(token)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return models.Session{}, false
+	}
+
+	session, err := c.service.GetActiveSession(claims.SessionID)
+	if err != nil {
+		if err == service.ErrInvalidSession {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return models.Session{}, false
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return models.Session{}, false
+	}
+	if session.AccountID != claims.AccountID {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": service.ErrInvalidSession.Error()})
+		return models.Session{}, false
+	}
+
+	return session, true
+}
+
+// currentAccount returns the account set by Authenticate.
+func currentAccount(ctx *gin.Context) models.Account {
+	return ctx.MustGet(accountContextKey).(models.Account)
+}
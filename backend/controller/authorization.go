@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const membershipContextKey = "membership"
+
+// householdEntityParams maps route params to the household-scoped record they address.
+var householdEntityParams = map[string]service.HouseholdEntity{
+	"choreId":        service.HouseholdEntityChore,
+	"accountChoreId": service.HouseholdEntityAccountChore,
+	"swapId":         service.HouseholdEntityChoreSwap,
+	"availabilityId": service.HouseholdEntityAvailability,
+	"itemId":         service.HouseholdEntityChecklistItem,
+	"splitId":        service.HouseholdEntitySplit,
+	"reviewId":       service.HouseholdEntityReview,
+	"notificationId": service.HouseholdEntityNotification,
+	"inviteId":       service.HouseholdEntityInvite,
+	"memberId":       service.HouseholdEntityMember,
+	"templateId":     service.HouseholdEntityTemplate,
+}
+
+// RequireHouseholdMember rejects callers who do not belong to :householdId and
+// any route whose target record lives in a different household.
+func (c *Controller) RequireHouseholdMember(ctx *gin.Context) {
+	householdId, err := uuid.Parse(ctx.Param("householdId"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership, err := c.service.GetHouseholdMembership(currentAccount(ctx).ID, householdId)
+	if err != nil {
+		if err == service.ErrNotHouseholdMember {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for param, entity := range householdEntityParams {
+		value := ctx.Param(param)
+		if value == "" {
+			continue
+		}
+
+		entityId, err := uuid.Parse(value)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		inHousehold, err := c.service.EntityInHousehold(entity, entityId, householdId)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !inHousehold {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Resource does not belong to this household"})
+			return
+		}
+	}
+
+	ctx.Set(membershipContextKey, membership)
+	ctx.Next()
+}
+
+// currentMembership returns the membership set by RequireHouseholdMember.
+func currentMembership(ctx *gin.Context) models.AccountHousehold {
+	return ctx.MustGet(membershipContextKey).(models.AccountHousehold)
+}
+
+// RequirePermission rejects members whose household role does not grant the
+// permission. It must run after RequireHouseholdMember.
+func (c *Controller) RequirePermission(permission models.HouseholdPermission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !currentMembership(ctx).Role.Can(permission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Your household role does not allow this action"})
+			return
+		}
+		ctx.Next()
+	}
+}
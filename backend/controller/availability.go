@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateMemberAvailability marks the caller as away for a range of days. Their
+// assignments in that range are handed to whoever is available.
+func (c *Controller) CreateMemberAvailability(ctx *gin.Context) {
+	var body models.CreateAvailabilityRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", body.StartDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date format. Use YYYY-MM-DD"})
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", body.EndDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format. Use YYYY-MM-DD"})
+		return
+	}
+
+	membership := currentMembership(ctx)
+	availability := models.MemberAvailability{
+		HouseholdID: membership.HouseholdID,
+		AccountID:   membership.AccountID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Note:        strings.TrimSpace(body.Note),
+	}
+
+	response, err := c.service.CreateMemberAvailability(&availability)
+	if err != nil {
+		if err == service.ErrInvalidAvailability {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *Controller) GetMemberAvailability(ctx *gin.Context) {
+	availability, err := c.service.GetMemberAvailability(currentMembership(ctx).HouseholdID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, availability)
+}
+
+func (c *Controller) DeleteMemberAvailability(ctx *gin.Context) {
+	availabilityId, err := uuid.Parse(ctx.Param("availabilityId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.DeleteMemberAvailability(availabilityId, currentAccount(ctx).ID); err != nil {
+		if err == service.ErrAvailabilityNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Availability deleted successfully"})
+}
+
+// GetCoverageReport shows how many turns each member covered for others who
+// were away, and how many were covered for them, in ?month=YYYY-MM.
+func (c *Controller) GetCoverageReport(ctx *gin.Context) {
+	// The service defaults to the household's current month
+	var month time.Time
+	if monthStr := ctx.Query("month"); monthStr != "" {
+		var err error
+		month, err = time.Parse("2006-01", monthStr)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM"})
+			return
+		}
+	}
+
+	report, err := c.service.GetCoverageReport(currentMembership(ctx).HouseholdID, month)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
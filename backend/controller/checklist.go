@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (c *Controller) GetChoreChecklist(ctx *gin.Context) {
+	choreId, err := uuid.Parse(ctx.Param("choreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checklist, err := c.service.GetChoreChecklist(choreId)
+	if err != nil {
+		c.checklistError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checklist)
+}
+
+func (c *Controller) UpdateChecklist(ctx *gin.Context) {
+	var body models.UpdateChecklistRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	choreId, err := uuid.Parse(ctx.Param("choreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items := make([]service.ChecklistItemInput, len(body.Items))
+	for i, item := range body.Items {
+		items[i].Title = item.Title
+		if item.ID != nil {
+			itemId, err := uuid.Parse(*item.ID)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			items[i].ID = &itemId
+		}
+	}
+
+	checklist, err := c.service.UpdateChecklist(choreId, items, body.RequireAll, currentAccount(ctx).ID)
+	if err != nil {
+		c.checklistError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checklist)
+}
+
+func (c *Controller) GetAssignmentChecklist(ctx *gin.Context) {
+	accountChoreId, err := uuid.Parse(ctx.Param("accountChoreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checklist, err := c.service.GetAssignmentChecklist(accountChoreId)
+	if err != nil {
+		c.checklistError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checklist)
+}
+
+func (c *Controller) CheckChecklistItem(ctx *gin.Context) {
+	var body models.CheckChecklistItemRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountChoreId, err := uuid.Parse(ctx.Param("accountChoreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	itemId, err := uuid.Parse(ctx.Param("itemId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checklist, err := c.service.CheckChecklistItem(accountChoreId, itemId, body.Checked, currentAccount(ctx).ID)
+	if err != nil {
+		c.checklistError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checklist)
+}
+
+func (c *Controller) checklistError(ctx *gin.Context, err error) {
+	switch err {
+	case service.ErrChoreNotFound, service.ErrChecklistItemNotFound:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.ErrChoreArchived, service.ErrAssignmentClosed:
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case service.ErrInvalidName:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
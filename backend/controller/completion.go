@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"chore-share/service"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DefaultUndoWindow is how long a completion can be undone when
+// COMPLETION_UNDO_WINDOW is not set.
+const DefaultUndoWindow = 10 * time.Minute
+
+func (c *Controller) UndoChoreCompletion(ctx *gin.Context) {
+	accountChoreId, err := uuid.Parse(ctx.Param("accountChoreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.UndoChoreCompletion(accountChoreId, currentAccount(ctx).ID, c.undoWindow); err != nil {
+		switch err {
+		case service.ErrAssignmentNotFound:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case service.ErrNotCompleter:
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case service.ErrNothingToUndo, service.ErrUndoWindowPassed, service.ErrCompletionReviewed:
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Chore completion undone"})
+}
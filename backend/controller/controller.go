@@ -1,54 +1,34 @@
 package controller
 
 import (
+	"chore-share/auth"
 	"chore-share/models"
 	"chore-share/service"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type Controller struct {
-	service service.DBService
+	service        service.DBService
+	google         *auth.GoogleVerifier
+	tokens         *auth.TokenManager
+	inviteLinkBase string
+	undoWindow     time.Duration
 }
 
-func NewController(service service.DBService) *Controller {
-	return &Controller{service: service}
-}
-
-func (c *Controller) CreateAccount(ctx *gin.Context) {
-	var body models.CreateAccountRequestBody
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	account := models.Account{
-		GoogleId: body.GoogleId,
-		Name:     body.Name,
-	}
-
-	response, err := c.service.CreateAccount(&account)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	ctx.JSON(http.StatusOK, response)
+func NewController(service service.DBService, google *auth.GoogleVerifier, tokens *auth.TokenManager, inviteLinkBase string, undoWindow time.Duration) *Controller {
+	return &Controller{service: service, google: google, tokens: tokens, inviteLinkBase: inviteLinkBase, undoWindow: undoWindow}
 }
 
 func (c *Controller) GetAccount(ctx *gin.Context) {
-	accountUUID := ctx.Param("accountId")
-	accountId, err := uuid.Parse(accountUUID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	account, err := c.service.GetAccount(accountId)
+	account, err := c.service.GetAccount(currentAccount(ctx).ID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -56,20 +36,6 @@ func (c *Controller) GetAccount(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, account)
 }
 
-func (c *Controller) GetAccountByGoogleId(ctx *gin.Context) {
-	googleId := ctx.Param("googleId")
-	account, err := c.service.GetAccountByGoogleId(googleId)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	ctx.JSON(http.StatusOK, account)
-}
-
 func (c *Controller) CreateChore(ctx *gin.Context) {
 	var body models.CreateChoreRequestBody
 
@@ -106,10 +72,33 @@ func (c *Controller) CreateChore(ctx *gin.Context) {
 		Type:          choreType,
 		Points:        body.Points,
 		EndDate:       body.EndDate,
+		EstimatedMinutes: body.EstimatedMinutes,
+		Strategy:      models.AssignmentStrategy(strings.ToUpper(body.Strategy)),
+		RequireChecklist: body.RequireChecklist,
+		RequireReview: body.RequireReview,
+	}
+
+	for i, title := range body.Checklist {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Checklist items need a title"})
+			return
+		}
+		chore.Checklist = append(chore.Checklist, models.ChecklistItem{Title: title, Position: i})
 	}
 
 	if choreType == models.ChoreTypeRecurring {
 		chore.FrequencyType = &frequencyType
+
+		if body.Recurrence != nil {
+			rule, err := recurrenceRule(body.Recurrence)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			chore.Recurrence = rule.String()
+			chore.RecurrenceStart = body.Recurrence.StartDate
+		}
 	}
 
 	// Create schedule for recurring chores
@@ -123,7 +112,12 @@ func (c *Controller) CreateChore(ctx *gin.Context) {
 		}
 	}
 
-	if err := c.service.CreateChore(chore, assignees, schedule); err != nil {
+	if err := c.service.CreateChore(chore, assignees, schedule, currentAccount(ctx).ID); err != nil {
+		if err == service.ErrAssigneeNotMember || err == service.ErrInvalidStrategy || err == service.ErrInvalidEstimate ||
+			err == service.ErrNoAssignees || errors.Is(err, service.ErrInvalidRecurrence) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -141,18 +135,21 @@ func (c *Controller) CreateHousehold(ctx *gin.Context) {
 		return
 	}
 
-	accountUUID := ctx.Param("accountId")
-	accountId, err := uuid.Parse(accountUUID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountId := currentAccount(ctx).ID
 
 	household := models.Household{
 		Password: body.Password,
 		Name:     body.Name,
+		Timezone: body.Timezone,
+	}
+	if household.Timezone == "" {
+		household.Timezone = currentAccount(ctx).Timezone
 	}
-	if err := c.service.CreateHousehold(&household); err != nil {
+	if err := c.service.CreateHousehold(&household, accountId); err != nil {
+		if err == service.ErrInvalidTimezone {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -160,11 +157,7 @@ func (c *Controller) CreateHousehold(ctx *gin.Context) {
 	response := models.CreateHouseholdResponse{
 		ID:   household.ID,
 		Name: household.Name,
-	}
-
-	if err := c.service.JoinHousehold(household.ID, accountId, body.Password); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		Timezone: household.Timezone,
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -183,13 +176,16 @@ func (c *Controller) JoinHousehold(ctx *gin.Context) {
 		return
 	}
 
-	accountId, err := uuid.Parse(ctx.Param("accountId"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountId := currentAccount(ctx).ID
 
-	if err := c.service.JoinHousehold(householdId, accountId, body.Password); err != nil {
+	if err := c.service.JoinHousehold(householdId, accountId, body.Password, ctx.ClientIP()); err != nil {
+		var throttled *service.JoinThrottledError
+		if errors.As(err, &throttled) {
+			retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+			ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+			ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retryAfterSeconds": retryAfter})
+			return
+		}
 		if err.Error() == "invalid password" {
 			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
@@ -202,12 +198,7 @@ func (c *Controller) JoinHousehold(ctx *gin.Context) {
 }
 
 func (c *Controller) GetAccountHouseholds(ctx *gin.Context) {
-	accountUUID := ctx.Param("accountId")
-	accountId, err := uuid.Parse(accountUUID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountId := currentAccount(ctx).ID
 
 	households, err := c.service.GetAccountHouseholds(accountId)
 	if err != nil {
@@ -249,12 +240,7 @@ func (c *Controller) GetHouseholdLeaderboard(ctx *gin.Context) {
 }
 
 func (c *Controller) GetAccountChores(ctx *gin.Context) {
-	accountUUID := ctx.Param("accountId")
-	accountId, err := uuid.Parse(accountUUID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountId := currentAccount(ctx).ID
 
 	householdUUID := ctx.Param("householdId")
 	householdId, err := uuid.Parse(householdUUID)
@@ -295,8 +281,49 @@ func (c *Controller) CompleteChore(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.CompleteChore(accountChoreId); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	// Photo proof is optional and arrives as multipart "photos" files
+	var photos []service.PhotoUpload
+	if ctx.ContentType() == "multipart/form-data" {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxCompletionBodyBytes)
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(form.File["photos"]) > service.MaxPhotosPerCompletion {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTooManyPhotos.Error()})
+			return
+		}
+		for _, header := range form.File["photos"] {
+			data, err := readUpload(header)
+			if err == service.ErrPhotoTooLarge {
+				ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+				return
+			}
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			photos = append(photos, service.PhotoUpload{Data: data})
+		}
+	}
+
+	if err := c.service.CompleteChore(accountChoreId, currentAccount(ctx).ID, photos); err != nil {
+		switch err {
+		case service.ErrChecklistIncomplete, service.ErrAssignmentBlocked:
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case service.ErrTooManyPhotos, service.ErrInvalidPhoto:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case service.ErrPhotoTooLarge:
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -311,7 +338,7 @@ func (c *Controller) SettleTransactionSplit(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.SettleTransactionSplit(splitId); err != nil {
+	if err := c.service.SettleTransactionSplit(splitId, currentAccount(ctx).ID); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -332,11 +359,7 @@ func (c *Controller) CreateTransaction(ctx *gin.Context) {
 		return
 	}
 
-	accountID, err := uuid.Parse(ctx.Param("accountId"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountID := currentAccount(ctx).ID
 
 	transaction := models.Transaction{
 		HouseholdID: householdID,
@@ -356,11 +379,7 @@ func (c *Controller) CreateTransaction(ctx *gin.Context) {
 }
 
 func (c *Controller) GetTransactionSummary(ctx *gin.Context) {
-	accountID, err := uuid.Parse(ctx.Param("accountId"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
-		return
-	}
+	accountID := currentAccount(ctx).ID
 
 	householdID, err := uuid.Parse(ctx.Param("householdId"))
 	if err != nil {
@@ -368,12 +387,14 @@ func (c *Controller) GetTransactionSummary(ctx *gin.Context) {
 		return
 	}
 
-	// Parse month from query params, default to current month if not provided
-	monthStr := ctx.DefaultQuery("month", time.Now().Format("2006-01"))
-	month, err := time.Parse("2006-01", monthStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM"})
-		return
+	// Parse month from query params, the service defaults to the household's current month
+	var month time.Time
+	if monthStr := ctx.Query("month"); monthStr != "" {
+		month, err = time.Parse("2006-01", monthStr)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format. Use YYYY-MM"})
+			return
+		}
 	}
 
 	summary, err := c.service.GetTransactionSummary(accountID, householdID, month)
@@ -386,11 +407,7 @@ func (c *Controller) GetTransactionSummary(ctx *gin.Context) {
 }
 
 func (c *Controller) GetNotifications(ctx *gin.Context) {
-	accountID, err := uuid.Parse(ctx.Param("accountId"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountID := currentAccount(ctx).ID
 
 	householdID, err := uuid.Parse(ctx.Param("householdId"))
 	if err != nil {
@@ -407,20 +424,28 @@ func (c *Controller) GetNotifications(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, notifications)
 }
 
-func (c *Controller) MarkNotificationAsSeen(ctx *gin.Context) {
-	accountID, err := uuid.Parse(ctx.Param("accountId"))
+func (c *Controller) GetDeletedHouseholdNotifications(ctx *gin.Context) {
+	notifications, err := c.service.GetDeletedHouseholdNotifications(currentAccount(ctx).ID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+func (c *Controller) MarkNotificationAsSeen(ctx *gin.Context) {
+	accountID := currentAccount(ctx).ID
+
 	notificationID, err := uuid.Parse(ctx.Param("notificationId"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := c.service.MarkNotificationAsSeen(accountID, notificationID); err != nil {
+	householdID := currentMembership(ctx).HouseholdID
+
+	if err := c.service.MarkNotificationAsSeen(accountID, householdID, notificationID); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -442,11 +467,7 @@ func (c *Controller) CreateChoreReview(ctx *gin.Context) {
 		return
 	}
 
-	accountId, err := uuid.Parse(ctx.Param("accountId"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountId := currentAccount(ctx).ID
 
 	householdId, err := uuid.Parse(ctx.Param("householdId"))
 	if err != nil {
@@ -458,12 +479,32 @@ func (c *Controller) CreateChoreReview(ctx *gin.Context) {
 		AccountChoreID: accountChoreId,
 		ReviewerID:      accountId,
 		HouseholdID:     householdId,
-		ReviewerStatus:  body.ReviewerStatus,
+		ReviewerStatus:  models.ReviewStatus(strings.ToUpper(body.ReviewerStatus)),
 		Review:          body.ReviewerComment,
+		Rating:          body.Rating,
+	}
+	for _, id := range body.MissedItemIDs {
+		itemId, err := uuid.Parse(id)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		review.MissedItems = append(review.MissedItems, models.ChecklistItem{ID: itemId})
 	}
 
 	if err := c.service.CreateChoreReview(&review); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err {
+		case service.ErrChecklistItemNotFound, service.ErrInvalidReviewStatus, service.ErrRejectionReasonRequired, service.ErrInvalidRating:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case service.ErrSelfReview:
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case service.ErrAssignmentNotFound:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case service.ErrAssignmentNotReviewable, service.ErrAlreadyReviewed:
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -495,26 +536,148 @@ func (c *Controller) MarkNotificationsAsSeen(ctx *gin.Context) {
 		return
 	}
 
-	accountID, err := uuid.Parse(ctx.Param("accountId"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+	accountID := currentAccount(ctx).ID
+	householdID := currentMembership(ctx).HouseholdID
 
 	// Convert string IDs to UUID
 	notificationIDs := make([]uuid.UUID, len(body.NotificationIDs))
 	for i, id := range body.NotificationIDs {
-		notificationIDs[i], err = uuid.Parse(id)
+		notificationID, err := uuid.Parse(id)
 		if err != nil {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID format"})
 			return
 		}
+		notificationIDs[i] = notificationID
 	}
 
-	if err := c.service.MarkNotificationsAsSeen(accountID, notificationIDs); err != nil {
+	if err := c.service.MarkNotificationsAsSeen(accountID, householdID, notificationIDs); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Notifications marked as seen"})
 }
+
+func (c *Controller) UpdateChore(ctx *gin.Context) {
+	var body models.UpdateChoreRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	choreId, err := uuid.Parse(ctx.Param("choreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	update := service.ChoreUpdate{
+		Title:            body.Title,
+		Description:      body.Description,
+		Points:           body.Points,
+		EndDate:          body.EndDate,
+		Schedule:         body.Schedule,
+		EstimatedMinutes: body.EstimatedMinutes,
+		RequireReview:    body.RequireReview,
+	}
+	if body.Strategy != nil {
+		strategy := models.AssignmentStrategy(strings.ToUpper(*body.Strategy))
+		update.Strategy = &strategy
+	}
+	if body.Frequency != nil {
+		frequencyType := models.FrequencyType(*body.Frequency)
+		update.Frequency = &frequencyType
+	}
+	if body.Recurrence != nil {
+		rule, err := recurrenceRule(body.Recurrence)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		recurrence := rule.String()
+		update.Recurrence = &recurrence
+		update.RecurrenceStart = body.Recurrence.StartDate
+	}
+	if body.AssigneeIDs != nil {
+		update.Assignees = make([]uuid.UUID, len(body.AssigneeIDs))
+		for i, id := range body.AssigneeIDs {
+			assigneeID, err := uuid.Parse(id)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			update.Assignees[i] = assigneeID
+		}
+	}
+
+	chore, err := c.service.UpdateChore(choreId, update, currentAccount(ctx).ID)
+	if err != nil {
+		switch {
+		case err == service.ErrChoreNotFound:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case err == service.ErrChoreArchived:
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case err == service.ErrInvalidName, err == service.ErrInvalidChorePoints, err == service.ErrNoAssignees,
+			err == service.ErrInvalidStrategy, err == service.ErrInvalidEstimate,
+			err == service.ErrAssigneeNotMember, errors.Is(err, service.ErrInvalidRecurrence):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, chore)
+}
+
+// ArchiveChore is the chore's delete endpoint. The chore and its history are
+// kept so it can be restored, only its open assignments are cancelled.
+func (c *Controller) ArchiveChore(ctx *gin.Context) {
+	choreId, err := uuid.Parse(ctx.Param("choreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	chore, err := c.service.ArchiveChore(choreId, currentAccount(ctx).ID)
+	if err != nil {
+		if err == service.ErrChoreNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, chore)
+}
+
+func (c *Controller) RestoreChore(ctx *gin.Context) {
+	choreId, err := uuid.Parse(ctx.Param("choreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	chore, err := c.service.RestoreChore(choreId, currentAccount(ctx).ID)
+	if err != nil {
+		if err == service.ErrChoreNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, chore)
+}
+
+func (c *Controller) GetArchivedChores(ctx *gin.Context) {
+	chores, err := c.service.GetArchivedChores(currentMembership(ctx).HouseholdID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, chores)
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (c *Controller) GetChoreDependencies(ctx *gin.Context) {
+	choreId, err := uuid.Parse(ctx.Param("choreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dependencies, err := c.service.GetChoreDependencies(choreId)
+	if err != nil {
+		c.dependencyError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dependencies)
+}
+
+func (c *Controller) UpdateChoreDependencies(ctx *gin.Context) {
+	var body models.UpdateChoreDependenciesRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	choreId, err := uuid.Parse(ctx.Param("choreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prerequisites := make([]uuid.UUID, len(body.PrerequisiteIDs))
+	for i, id := range body.PrerequisiteIDs {
+		prerequisiteId, err := uuid.Parse(id)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		prerequisites[i] = prerequisiteId
+	}
+
+	dependencies, err := c.service.UpdateChoreDependencies(choreId, prerequisites, currentAccount(ctx).ID)
+	if err != nil {
+		c.dependencyError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dependencies)
+}
+
+func (c *Controller) dependencyError(ctx *gin.Context, err error) {
+	switch err {
+	case service.ErrChoreNotFound:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.ErrInvalidDependency:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrChoreArchived, service.ErrDependencyCycle:
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *Controller) UpdateHousehold(ctx *gin.Context) {
+	var body models.UpdateHouseholdRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := c.service.UpdateHousehold(currentMembership(ctx).HouseholdID, body, currentAccount(ctx).ID)
+	if err != nil {
+		if err == service.ErrInvalidName || err == service.ErrInvalidHouseholdPassword || err == service.ErrInvalidTimezone {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, household)
+}
+
+func (c *Controller) DeleteHousehold(ctx *gin.Context) {
+	membership := currentMembership(ctx)
+	if err := c.service.DeleteHousehold(membership.HouseholdID, membership.AccountID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Household deleted successfully"})
+}
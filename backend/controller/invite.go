@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultInviteExpiry = 72 * time.Hour
+	maxInviteExpiry     = 30 * 24 * time.Hour
+)
+
+func (c *Controller) CreateHouseholdInvite(ctx *gin.Context) {
+	var body models.CreateHouseholdInviteRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expiresIn := defaultInviteExpiry
+	if body.ExpiresInHours != 0 {
+		expiresIn = time.Duration(body.ExpiresInHours) * time.Hour
+	}
+	if expiresIn <= 0 || expiresIn > maxInviteExpiry {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invite expiry must be between 1 hour and 30 days"})
+		return
+	}
+
+	maxUses := body.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if maxUses < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Max uses must be positive"})
+		return
+	}
+
+	invite := models.HouseholdInvite{
+		HouseholdID: currentMembership(ctx).HouseholdID,
+		CreatedByID: currentAccount(ctx).ID,
+		MaxUses:     maxUses,
+		ExpiresAt:   time.Now().Add(expiresIn),
+	}
+	if email := strings.TrimSpace(body.Email); email != "" {
+		invite.Email = &email
+	}
+
+	if err := c.service.CreateHouseholdInvite(&invite); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.inviteResponse(invite))
+}
+
+func (c *Controller) GetHouseholdInvites(ctx *gin.Context) {
+	invites, err := c.service.GetActiveHouseholdInvites(currentMembership(ctx).HouseholdID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]models.HouseholdInviteResponse, len(invites))
+	for i, invite := range invites {
+		response[i] = c.inviteResponse(invite)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *Controller) RevokeHouseholdInvite(ctx *gin.Context) {
+	inviteId, err := uuid.Parse(ctx.Param("inviteId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.RevokeHouseholdInvite(inviteId, currentAccount(ctx).ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Invite revoked successfully"})
+}
+
+func (c *Controller) AcceptHouseholdInvite(ctx *gin.Context) {
+	household, err := c.service.AcceptHouseholdInvite(ctx.Param("code"), currentAccount(ctx).ID)
+	if err != nil {
+		switch err {
+		case service.ErrInviteNotFound:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case service.ErrInviteExpired, service.ErrInviteRevoked, service.ErrInviteExhausted:
+			ctx.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		case service.ErrInviteEmailMismatch:
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case service.ErrAlreadyHouseholdMember:
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.HouseholdResponse{
+		ID:   household.ID,
+		Name: household.Name,
+	})
+}
+
+func (c *Controller) inviteResponse(invite models.HouseholdInvite) models.HouseholdInviteResponse {
+	return models.HouseholdInviteResponse{
+		ID:            invite.ID,
+		HouseholdID:   invite.HouseholdID,
+		Code:          invite.Code,
+		Link:          c.inviteLinkBase + invite.Code,
+		Email:         invite.Email,
+		MaxUses:       invite.MaxUses,
+		UseCount:      invite.UseCount,
+		ExpiresAt:     invite.ExpiresAt,
+		CreatedByID:   invite.CreatedByID,
+		CreatedByName: invite.CreatedBy.Name,
+		CreatedAt:     invite.CreatedAt,
+	}
+}
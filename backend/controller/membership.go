@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"chore-share/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (c *Controller) LeaveHousehold(ctx *gin.Context) {
+	membership := currentMembership(ctx)
+	c.removeMember(ctx, membership.AccountID)
+}
+
+func (c *Controller) RemoveHouseholdMember(ctx *gin.Context) {
+	memberId, err := uuid.Parse(ctx.Param("memberId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if memberId == currentAccount(ctx).ID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Use the leave endpoint to leave a household"})
+		return
+	}
+
+	c.removeMember(ctx, memberId)
+}
+
+// removeMember runs the departure workflow. Pass ?settleBalances=true to mark
+// the member's unsettled splits as settled instead of getting a 409 listing them.
+func (c *Controller) removeMember(ctx *gin.Context, memberId uuid.UUID) {
+	membership := currentMembership(ctx)
+	settleBalances := ctx.Query("settleBalances") == "true"
+
+	response, err := c.service.RemoveHouseholdMember(membership.HouseholdID, memberId, membership.AccountID, settleBalances)
+	if err != nil {
+		switch err {
+		case service.ErrOutstandingBalances:
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":             err.Error(),
+				"outstandingSplits": response.OutstandingSplits,
+			})
+		case service.ErrOwnerMustTransfer, service.ErrCannotRemoveOwner:
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case service.ErrNotHouseholdMember:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *Controller) GetJoinAttempts(ctx *gin.Context) {
+	attempts, err := c.service.GetJoinAttempts(currentMembership(ctx).HouseholdID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attempts)
+}
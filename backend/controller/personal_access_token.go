@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"chore-share/auth"
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// tokenScopeRoutes lists the only routes personal access tokens may call and
+// the scope each one needs. Everything else requires a user session.
+var tokenScopeRoutes = map[string]models.TokenScope{
+	"GET /api/accounts/:accountId/households/:householdId/chores":                          models.TokenScopeChoresRead,
+	"GET /api/households/:householdId/chores":                                              models.TokenScopeChoresRead,
+	"GET /api/households/:householdId/leaderboard":                                         models.TokenScopeChoresRead,
+	"POST /api/accounts/:accountId/households/:householdId/chores":                         models.TokenScopeChoresCreate,
+	"PUT /api/accounts/:accountId/households/:householdId/chores/:accountChoreId/complete": models.TokenScopeChoresComplete,
+	"GET /api/accounts/:accountId/households/:householdId/transactions/summary":            models.TokenScopeTransactionsRead,
+	"POST /api/accounts/:accountId/households/:householdId/transactions":                   models.TokenScopeTransactionsCreate,
+}
+
+func (c *Controller) authenticatePersonalAccessToken(ctx *gin.Context, token string) (models.PersonalAccessToken, bool) {
+	accessToken, err := c.service.GetActivePersonalAccessToken(auth.HashToken(token))
+	if err != nil {
+		if err == service.ErrInvalidToken {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return models.PersonalAccessToken{}, false
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return models.PersonalAccessToken{}, false
+	}
+
+	scope, allowed := tokenScopeRoutes[ctx.Request.Method+" "+ctx.FullPath()]
+	if !allowed || !accessToken.HasScope(scope) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Token is not allowed to call this endpoint"})
+		return models.PersonalAccessToken{}, false
+	}
+	if ctx.Param("householdId") != accessToken.HouseholdID.String() {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Token is not valid for this household"})
+		return models.PersonalAccessToken{}, false
+	}
+
+	return accessToken, true
+}
+
+func (c *Controller) CreatePersonalAccessToken(ctx *gin.Context) {
+	var body models.CreatePersonalAccessTokenRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	householdId, err := uuid.Parse(body.HouseholdID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plaintext, err := auth.NewPersonalAccessToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	accessToken := models.PersonalAccessToken{
+		AccountID:   currentAccount(ctx).ID,
+		HouseholdID: householdId,
+		Name:        body.Name,
+		TokenHash:   auth.HashToken(plaintext),
+		Prefix:      plaintext[:len(auth.PersonalAccessTokenPrefix)+6],
+		Scopes:      strings.Join(body.Scopes, ","),
+	}
+	if body.ExpiresInDays < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Expiry must be positive"})
+		return
+	}
+	if body.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, body.ExpiresInDays)
+		accessToken.ExpiresAt = &expiresAt
+	}
+
+	if err := c.service.CreatePersonalAccessToken(&accessToken); err != nil {
+		switch err {
+		case service.ErrInvalidTokenScope:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case service.ErrNotHouseholdMember:
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	response := personalAccessTokenResponse(accessToken)
+	response.Token = plaintext
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *Controller) GetPersonalAccessTokens(ctx *gin.Context) {
+	tokens, err := c.service.GetPersonalAccessTokens(currentAccount(ctx).ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]models.PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		response[i] = personalAccessTokenResponse(token)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *Controller) RevokePersonalAccessToken(ctx *gin.Context) {
+	tokenId, err := uuid.Parse(ctx.Param("tokenId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.RevokePersonalAccessToken(currentAccount(ctx).ID, tokenId); err != nil {
+		if err == service.ErrTokenNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Token revoked successfully"})
+}
+
+func personalAccessTokenResponse(token models.PersonalAccessToken) models.PersonalAccessTokenResponse {
+	return models.PersonalAccessTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		HouseholdID: token.HouseholdID,
+		Prefix:      token.Prefix,
+		Scopes:      token.ScopeList(),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"chore-share/service"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (c *Controller) GetAssignmentPhotos(ctx *gin.Context) {
+	accountChoreId, err := uuid.Parse(ctx.Param("accountChoreId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	photos, err := c.service.GetAssignmentPhotos(accountChoreId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, photos)
+}
+
+// maxCompletionBodyBytes caps a completion request: a full set of photos
+// plus room for the multipart framing.
+const maxCompletionBodyBytes = service.MaxPhotosPerCompletion*service.MaxPhotoBytes + 1<<20
+
+// readUpload reads one uploaded photo, stopping as soon as it is known to be
+// over the size limit.
+func readUpload(header *multipart.FileHeader) ([]byte, error) {
+	if header.Size > service.MaxPhotoBytes {
+		return nil, service.ErrPhotoTooLarge
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, service.MaxPhotoBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > service.MaxPhotoBytes {
+		return nil, service.ErrPhotoTooLarge
+	}
+	return data, nil
+}
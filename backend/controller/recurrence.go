@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/recurrence"
+	"errors"
+	"strings"
+	"time"
+)
+
+// recurrenceRule turns the chore API's recurrence fields into a rule. The
+// result is validated again by the service when the chore is created.
+func recurrenceRule(body *models.RecurrenceRequestBody) (*recurrence.Rule, error) {
+	if body.RRule != "" {
+		return recurrence.Parse(body.RRule)
+	}
+
+	rule := &recurrence.Rule{
+		Frequency: recurrence.Frequency(strings.ToUpper(body.Frequency)),
+		Interval:  body.Interval,
+		WeekStart: time.Monday,
+	}
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+
+	for _, day := range body.DaysOfWeek {
+		if day < 1 || day > 7 {
+			return nil, errors.New("days of week must be between 1 (Monday) and 7 (Sunday)")
+		}
+		rule.ByDay = append(rule.ByDay, recurrence.WeekdayNum{
+			Ordinal: body.WeekOfMonth,
+			Weekday: time.Weekday(day % 7),
+		})
+	}
+	if body.WeekOfMonth != 0 && len(body.DaysOfWeek) == 0 {
+		return nil, errors.New("weekOfMonth needs at least one day of the week")
+	}
+	if body.DayOfMonth != 0 {
+		rule.ByMonthDay = []int{body.DayOfMonth}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (c *Controller) UpdateMemberRole(ctx *gin.Context) {
+	var body models.UpdateMemberRoleRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	memberId, err := uuid.Parse(ctx.Param("memberId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = c.service.UpdateMemberRole(currentMembership(ctx).HouseholdID, memberId, models.HouseholdRole(body.Role), currentAccount(ctx).ID)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidRole, service.ErrCannotChangeOwnerRole:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case service.ErrNotHouseholdMember:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Member role updated successfully"})
+}
+
+func (c *Controller) TransferHouseholdOwnership(ctx *gin.Context) {
+	var body models.TransferOwnershipRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	newOwnerId, err := uuid.Parse(body.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership := currentMembership(ctx)
+	if newOwnerId == membership.AccountID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "You already own this household"})
+		return
+	}
+
+	if err := c.service.TransferHouseholdOwnership(membership.HouseholdID, membership.AccountID, newOwnerId); err != nil {
+		if err == service.ErrNotHouseholdMember {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Ownership transferred successfully"})
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSwapExpiry = 48 * time.Hour
+	maxSwapExpiry     = 14 * 24 * time.Hour
+)
+
+func (c *Controller) CreateChoreSwap(ctx *gin.Context) {
+	var body models.CreateChoreSwapRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expiresIn := defaultSwapExpiry
+	if body.ExpiresInHours != 0 {
+		expiresIn = time.Duration(body.ExpiresInHours) * time.Hour
+	}
+	if expiresIn <= 0 || expiresIn > maxSwapExpiry {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Swap expiry must be between 1 hour and 14 days"})
+		return
+	}
+
+	offeredId, err := uuid.Parse(body.OfferedAssignmentID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	recipientId, err := uuid.Parse(body.RecipientID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	swap := models.ChoreSwap{
+		HouseholdID:         currentMembership(ctx).HouseholdID,
+		RequesterID:         currentAccount(ctx).ID,
+		RecipientID:         recipientId,
+		OfferedAssignmentID: offeredId,
+		Message:             strings.TrimSpace(body.Message),
+		ExpiresAt:           time.Now().Add(expiresIn),
+	}
+	if body.RequestedAssignmentID != nil {
+		requestedId, err := uuid.Parse(*body.RequestedAssignmentID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		swap.RequestedAssignmentID = &requestedId
+	}
+
+	if err := c.service.CreateChoreSwap(&swap); err != nil {
+		c.swapError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Swap requested successfully",
+		"id":      swap.ID,
+	})
+}
+
+// GetChoreSwaps lists the swaps the caller sent or received, optionally
+// filtered with ?status=.
+func (c *Controller) GetChoreSwaps(ctx *gin.Context) {
+	membership := currentMembership(ctx)
+	status := models.SwapStatus(strings.ToUpper(ctx.Query("status")))
+
+	swaps, err := c.service.GetChoreSwaps(membership.HouseholdID, membership.AccountID, status)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaps)
+}
+
+func (c *Controller) AcceptChoreSwap(ctx *gin.Context) {
+	c.answerChoreSwap(ctx, c.service.AcceptChoreSwap)
+}
+
+func (c *Controller) DeclineChoreSwap(ctx *gin.Context) {
+	c.answerChoreSwap(ctx, c.service.DeclineChoreSwap)
+}
+
+func (c *Controller) CancelChoreSwap(ctx *gin.Context) {
+	c.answerChoreSwap(ctx, c.service.CancelChoreSwap)
+}
+
+func (c *Controller) answerChoreSwap(ctx *gin.Context, answer func(uuid.UUID, uuid.UUID) (models.ChoreSwapResponse, error)) {
+	swapId, err := uuid.Parse(ctx.Param("swapId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	swap, err := answer(swapId, currentAccount(ctx).ID)
+	if err != nil {
+		c.swapError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swap)
+}
+
+func (c *Controller) swapError(ctx *gin.Context, err error) {
+	switch err {
+	case service.ErrSwapNotFound:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.ErrSwapNotRecipient, service.ErrSwapNotRequester:
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case service.ErrSwapNotPending, service.ErrSwapConflict, service.ErrSwapExpired, service.ErrInvalidSwap:
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case service.ErrAssigneeNotMember:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
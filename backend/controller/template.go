@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"chore-share/models"
+	"chore-share/service"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (c *Controller) CreateChoreTemplate(ctx *gin.Context) {
+	var body models.CreateChoreTemplateRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership := currentMembership(ctx)
+	template := models.ChoreTemplate{
+		HouseholdID:      membership.HouseholdID,
+		CreatedByID:      membership.AccountID,
+		Title:            body.Title,
+		Description:      body.Description,
+		Category:         body.Category,
+		Type:             models.ChoreType(strings.ToUpper(body.Type)),
+		Points:           body.Points,
+		EstimatedMinutes: body.EstimatedMinutes,
+		Shared:           body.Shared,
+	}
+	if template.Type == models.ChoreTypeRecurring {
+		if body.Frequency != "" {
+			frequencyType := models.FrequencyType(strings.ToUpper(body.Frequency))
+			template.FrequencyType = &frequencyType
+		}
+		template.SetScheduleDays(body.Schedule)
+
+		if body.Recurrence != nil {
+			rule, err := recurrenceRule(body.Recurrence)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			template.Recurrence = rule.String()
+		}
+	}
+
+	if err := c.service.CreateChoreTemplate(&template); err != nil {
+		c.templateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Chore template created successfully",
+		"id":      template.ID,
+	})
+}
+
+func (c *Controller) GetChoreTemplates(ctx *gin.Context) {
+	membership := currentMembership(ctx)
+	templates, err := c.service.GetChoreTemplates(membership.HouseholdID, membership.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, templates)
+}
+
+// InstantiateChoreTemplate creates a chore in this household from one of the
+// templates GetChoreTemplates lists.
+func (c *Controller) InstantiateChoreTemplate(ctx *gin.Context) {
+	var body models.InstantiateChoreTemplateRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	templateId, err := uuid.Parse(ctx.Param("templateId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	instance := service.TemplateInstance{
+		EndDate:   body.EndDate,
+		StartDate: body.StartDate,
+		Strategy:  models.AssignmentStrategy(strings.ToUpper(body.Strategy)),
+		Assignees: make([]uuid.UUID, len(body.AssigneeIDs)),
+	}
+	for i, id := range body.AssigneeIDs {
+		assigneeID, err := uuid.Parse(id)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		instance.Assignees[i] = assigneeID
+	}
+
+	membership := currentMembership(ctx)
+	chore, err := c.service.InstantiateChoreTemplate(templateId, membership.HouseholdID, instance, membership.AccountID)
+	if err != nil {
+		c.templateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Chore created successfully",
+		"id":      chore.ID,
+	})
+}
+
+func (c *Controller) DeleteChoreTemplate(ctx *gin.Context) {
+	templateId, err := uuid.Parse(ctx.Param("templateId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership := currentMembership(ctx)
+	if err := c.service.DeleteChoreTemplate(templateId, membership.HouseholdID, membership.AccountID); err != nil {
+		c.templateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Chore template deleted successfully"})
+}
+
+func (c *Controller) templateError(ctx *gin.Context, err error) {
+	switch {
+	case err == service.ErrTemplateNotFound:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err == service.ErrBuiltInTemplate:
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case err == service.ErrInvalidName, err == service.ErrInvalidChorePoints, err == service.ErrInvalidEstimate,
+		err == service.ErrInvalidTemplate, err == service.ErrEndDateRequired, err == service.ErrInvalidStrategy,
+		err == service.ErrAssigneeNotMember, err == service.ErrNoAssignees, errors.Is(err, service.ErrInvalidRecurrence):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"chore-share/auth"
 	"chore-share/controller"
+	"chore-share/models"
+	"chore-share/scheduler"
 	"chore-share/service"
+	"chore-share/storage"
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -18,8 +26,108 @@ func main() {
 
 	dbUrl := os.Getenv("DATABASE_URL")
 
-	dbService := service.NewDBService(dbUrl)
-	controller := controller.NewController(dbService)
+	sessionSecret := os.Getenv("SESSION_SECRET")
+	if sessionSecret == "" {
+		log.Fatal("SESSION_SECRET must be set")
+	}
+
+	// GOOGLE_JWKS_FILE lets local setups verify tokens against injected keys
+	var googleKeys auth.KeySource = auth.NewRemoteKeySource(auth.GoogleJWKSURL)
+	if jwksFile := os.Getenv("GOOGLE_JWKS_FILE"); jwksFile != "" {
+		keys, err := auth.LoadJWKSFile(jwksFile)
+		if err != nil {
+			log.Fatalf("Error loading JWKS file: %v", err)
+		}
+		googleKeys = keys
+	}
+	clientIDs := auth.ClientIDs(strings.Split(os.Getenv("GOOGLE_CLIENT_IDS"), ","))
+	if len(clientIDs) == 0 {
+		log.Fatal("GOOGLE_CLIENT_IDS must list at least one client ID")
+	}
+	googleVerifier := auth.NewGoogleVerifier(clientIDs, googleKeys)
+	tokenManager := auth.NewTokenManager([]byte(sessionSecret))
+
+	inviteLinkBase := os.Getenv("INVITE_LINK_BASE_URL")
+	if inviteLinkBase == "" {
+		inviteLinkBase = "myapp://invite/"
+	}
+
+	// PHOTO_STORAGE picks where photo proof is kept: "local" (the default) serves
+	// PHOTO_STORAGE_DIR from /files, publicly reachable at PHOTO_BASE_URL with
+	// links signed by PHOTO_URL_SECRET, and "s3" uses an S3-compatible bucket
+	var photoStore storage.BlobStore
+	var localFiles *storage.LocalStore
+	switch os.Getenv("PHOTO_STORAGE") {
+	case "", "local":
+		dir := os.Getenv("PHOTO_STORAGE_DIR")
+		if dir == "" {
+			dir = "uploads"
+		}
+		baseURL := os.Getenv("PHOTO_BASE_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:8080/files"
+		}
+		// Kept apart from SESSION_SECRET so a leaked photo link key can't mint sessions
+		photoURLSecret := os.Getenv("PHOTO_URL_SECRET")
+		if photoURLSecret == "" {
+			log.Fatal("PHOTO_URL_SECRET must be set when photos are stored locally")
+		}
+		local, err := storage.NewLocalStore(dir, baseURL, []byte(photoURLSecret))
+		if err != nil {
+			log.Fatalf("Error creating photo storage: %v", err)
+		}
+		localFiles = local
+		photoStore = local
+	case "s3":
+		s3, err := storage.NewS3Store(storage.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       os.Getenv("S3_PATH_STYLE") == "true",
+		})
+		if err != nil {
+			log.Fatalf("Error creating photo storage: %v", err)
+		}
+		photoStore = s3
+	default:
+		log.Fatalf("Invalid PHOTO_STORAGE: %s", os.Getenv("PHOTO_STORAGE"))
+	}
+
+	// SCHEDULER_INTERVAL is a Go duration, ASSIGNMENT_HORIZON_DAYS how far ahead
+	// recurring chores are planned, both by the scheduler and when chores change
+	schedulerInterval := scheduler.DefaultInterval
+	if value := os.Getenv("SCHEDULER_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid SCHEDULER_INTERVAL: %v", err)
+		}
+		schedulerInterval = interval
+	}
+	horizonDays := scheduler.DefaultHorizonDays
+	if value := os.Getenv("ASSIGNMENT_HORIZON_DAYS"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days <= 0 {
+			log.Fatalf("Invalid ASSIGNMENT_HORIZON_DAYS: %s", value)
+		}
+		horizonDays = days
+	}
+
+	dbService := service.NewDBService(dbUrl, photoStore, horizonDays)
+	scheduler.New(dbService, schedulerInterval, horizonDays).Start(context.Background())
+
+	// COMPLETION_UNDO_WINDOW is a Go duration, how long members can take back a completion
+	undoWindow := controller.DefaultUndoWindow
+	if value := os.Getenv("COMPLETION_UNDO_WINDOW"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid COMPLETION_UNDO_WINDOW: %v", err)
+		}
+		undoWindow = window
+	}
+
+	controller := controller.NewController(dbService, googleVerifier, tokenManager, inviteLinkBase, undoWindow)
 
 	r := gin.Default()
 	r.GET("/ping", func(c *gin.Context) {
@@ -27,25 +135,82 @@ func main() {
 			"message": "pong",
 		})
 	})
-	r.POST("/api/accounts", controller.CreateAccount)
-	r.POST("/api/accounts/:accountId/households/:householdId/chores", controller.CreateChore)
-	r.GET("/api/accounts/:accountId/households/:householdId/chores", controller.GetAccountChores)
-	r.GET("/api/households/:householdId/chores", controller.GetHouseholdChores)
-	r.GET("/api/households/:householdId/leaderboard", controller.GetHouseholdLeaderboard)
-	r.POST("/api/accounts/:accountId/households", controller.CreateHousehold)
-	r.POST("/api/accounts/:accountId/households/join", controller.JoinHousehold)
-	r.GET("/api/accounts/:accountId", controller.GetAccount)
-	r.GET("/api/accounts/google/:googleId", controller.GetAccountByGoogleId)
-	r.GET("/api/accounts/:accountId/households", controller.GetAccountHouseholds)
-	r.GET("/api/households/:householdId/members", controller.GetHouseholdMembers)
-	r.PUT("/api/accounts/:accountId/households/:householdId/chores/:accountChoreId/complete", controller.CompleteChore)
-	r.POST("/api/accounts/:accountId/households/:householdId/transactions", controller.CreateTransaction)
-	r.GET("/api/accounts/:accountId/households/:householdId/transactions/summary", controller.GetTransactionSummary)
-	r.PUT("/api/accounts/:accountId/households/:householdId/transactions/:splitId/settle", controller.SettleTransactionSplit)
-	r.GET("/api/accounts/:accountId/households/:householdId/notifications", controller.GetNotifications)
-	r.PUT("/api/accounts/:accountId/households/:householdId/notifications/:notificationId/seen", controller.MarkNotificationAsSeen)
-	r.PUT("/api/accounts/:accountId/households/:householdId/notifications/seen", controller.MarkNotificationsAsSeen)
-	r.POST("/api/accounts/:accountId/households/:householdId/chores/:accountChoreId/reviews", controller.CreateChoreReview)
-	r.GET("/api/accounts/:accountId/households/:householdId/chores/:accountChoreId/reviews/:reviewId", controller.GetChoreReview)
+	if localFiles != nil {
+		r.GET("/files/*key", gin.WrapH(http.StripPrefix("/files", localFiles)))
+	}
+	r.POST("/api/auth/google", controller.SignInWithGoogle)
+	r.POST("/api/auth/refresh", controller.RefreshSession)
+
+	api := r.Group("/api", controller.Authenticate)
+	api.POST("/auth/logout", controller.SignOut)
+	api.GET("/accounts/:accountId", controller.GetAccount)
+	api.PUT("/accounts/:accountId", controller.UpdateAccount)
+	api.DELETE("/accounts/:accountId", controller.DeleteAccount)
+	api.GET("/accounts/:accountId/export", controller.ExportAccountData)
+	api.POST("/accounts/:accountId/tokens", controller.CreatePersonalAccessToken)
+	api.GET("/accounts/:accountId/tokens", controller.GetPersonalAccessTokens)
+	api.DELETE("/accounts/:accountId/tokens/:tokenId", controller.RevokePersonalAccessToken)
+	api.GET("/accounts/:accountId/households", controller.GetAccountHouseholds)
+	api.GET("/accounts/:accountId/deleted-households/notifications", controller.GetDeletedHouseholdNotifications)
+	api.POST("/accounts/:accountId/households", controller.CreateHousehold)
+	api.POST("/accounts/:accountId/households/join", controller.JoinHousehold)
+	api.POST("/accounts/:accountId/invites/:code/accept", controller.AcceptHouseholdInvite)
+
+	household := api.Group("/households/:householdId", controller.RequireHouseholdMember)
+	household.GET("/chores", controller.GetHouseholdChores)
+	household.GET("/leaderboard", controller.GetHouseholdLeaderboard)
+	household.GET("/members", controller.GetHouseholdMembers)
+	household.GET("/audit-log", controller.GetAuditLog)
+	household.GET("/availability", controller.GetMemberAvailability)
+	household.GET("/coverage", controller.GetCoverageReport)
+	household.GET("/chore-templates", controller.GetChoreTemplates)
+	household.POST("/chore-templates", controller.CreateChoreTemplate)
+	household.DELETE("/chore-templates/:templateId", controller.RequirePermission(models.PermissionManageChores), controller.DeleteChoreTemplate)
+	household.POST("/chore-templates/:templateId/chores", controller.InstantiateChoreTemplate)
+	household.GET("/chores/archived", controller.GetArchivedChores)
+	household.PUT("/chores/:choreId", controller.RequirePermission(models.PermissionManageChores), controller.UpdateChore)
+	household.DELETE("/chores/:choreId", controller.RequirePermission(models.PermissionManageChores), controller.ArchiveChore)
+	household.POST("/chores/:choreId/restore", controller.RequirePermission(models.PermissionManageChores), controller.RestoreChore)
+	household.GET("/chores/:choreId/checklist", controller.GetChoreChecklist)
+	household.PUT("/chores/:choreId/checklist", controller.RequirePermission(models.PermissionManageChores), controller.UpdateChecklist)
+	household.GET("/chores/:choreId/reviews", controller.GetChoreReviews)
+	household.GET("/chores/:choreId/dependencies", controller.GetChoreDependencies)
+	household.PUT("/chores/:choreId/dependencies", controller.RequirePermission(models.PermissionManageChores), controller.UpdateChoreDependencies)
+	household.GET("/members/:memberId/reviews", controller.GetMemberReviews)
+
+	accountHousehold := api.Group("/accounts/:accountId/households/:householdId", controller.RequireHouseholdMember)
+	accountHousehold.PUT("", controller.RequirePermission(models.PermissionManageHousehold), controller.UpdateHousehold)
+	accountHousehold.DELETE("", controller.RequirePermission(models.PermissionDeleteHousehold), controller.DeleteHousehold)
+	accountHousehold.POST("/chores", controller.CreateChore)
+	accountHousehold.GET("/chores", controller.GetAccountChores)
+	accountHousehold.PUT("/chores/:accountChoreId/complete", controller.CompleteChore)
+	accountHousehold.DELETE("/chores/:accountChoreId/complete", controller.UndoChoreCompletion)
+	accountHousehold.GET("/chores/:accountChoreId/checklist", controller.GetAssignmentChecklist)
+	accountHousehold.GET("/chores/:accountChoreId/photos", controller.GetAssignmentPhotos)
+	accountHousehold.PUT("/chores/:accountChoreId/checklist/:itemId", controller.CheckChecklistItem)
+	accountHousehold.POST("/transactions", controller.CreateTransaction)
+	accountHousehold.GET("/transactions/summary", controller.GetTransactionSummary)
+	accountHousehold.PUT("/transactions/:splitId/settle", controller.SettleTransactionSplit)
+	accountHousehold.GET("/notifications", controller.GetNotifications)
+	accountHousehold.PUT("/notifications/:notificationId/seen", controller.MarkNotificationAsSeen)
+	accountHousehold.PUT("/notifications/seen", controller.MarkNotificationsAsSeen)
+	accountHousehold.POST("/chores/:accountChoreId/reviews", controller.CreateChoreReview)
+	accountHousehold.GET("/chores/:accountChoreId/reviews", controller.GetAssignmentReviews)
+	accountHousehold.GET("/chores/:accountChoreId/reviews/:reviewId", controller.GetChoreReview)
+	accountHousehold.POST("/invites", controller.RequirePermission(models.PermissionManageInvites), controller.CreateHouseholdInvite)
+	accountHousehold.GET("/invites", controller.RequirePermission(models.PermissionManageInvites), controller.GetHouseholdInvites)
+	accountHousehold.DELETE("/invites/:inviteId", controller.RequirePermission(models.PermissionManageInvites), controller.RevokeHouseholdInvite)
+	accountHousehold.PUT("/members/:memberId/role", controller.RequirePermission(models.PermissionManageRoles), controller.UpdateMemberRole)
+	accountHousehold.GET("/join-attempts", controller.RequirePermission(models.PermissionManageMembers), controller.GetJoinAttempts)
+	accountHousehold.POST("/swaps", controller.CreateChoreSwap)
+	accountHousehold.GET("/swaps", controller.GetChoreSwaps)
+	accountHousehold.PUT("/swaps/:swapId/accept", controller.AcceptChoreSwap)
+	accountHousehold.PUT("/swaps/:swapId/decline", controller.DeclineChoreSwap)
+	accountHousehold.PUT("/swaps/:swapId/cancel", controller.CancelChoreSwap)
+	accountHousehold.POST("/availability", controller.CreateMemberAvailability)
+	accountHousehold.DELETE("/availability/:availabilityId", controller.DeleteMemberAvailability)
+	accountHousehold.POST("/leave", controller.LeaveHousehold)
+	accountHousehold.DELETE("/members/:memberId", controller.RequirePermission(models.PermissionManageMembers), controller.RemoveHouseholdMember)
+	accountHousehold.POST("/ownership/transfer", controller.RequirePermission(models.PermissionManageRoles), controller.TransferHouseholdOwnership)
 	r.Run() // listen and serve on 0.0.0.0:8080 (for windows "localhost:8080")
-}
\ No newline at end of file
+}
@@ -10,6 +10,10 @@ type Account struct {
 	ID        uuid.UUID    `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
 	GoogleId  string    `gorm:"unique" json:"google_id"`
 	Name      string    `gorm:"not null; size:255" json:"name"`
+	Email     string    `gorm:"size:255" json:"email"`
+	AvatarURL string    `gorm:"size:2048" json:"avatar_url"`
+	Timezone  string    `gorm:"size:64" json:"timezone"`
+	DeletedAt *time.Time `json:"deleted_at"`
 	CreatedAt time.Time `gorm:"not null; default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time `gorm:"not null; default:CURRENT_TIMESTAMP" json:"updated_at"`
 	Households  []Household `gorm:"many2many:account_households;"`
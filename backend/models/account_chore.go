@@ -9,23 +9,27 @@ import (
 type AssignmentStatus string
 
 const (
-	AssignmentStatusPending   AssignmentStatus = "PENDING"   // Current, needs to be done
-	AssignmentStatusCompleted AssignmentStatus = "COMPLETED" // Done
-	AssignmentStatusOverdue   AssignmentStatus = "OVERDUE"   // Past due date
-	AssignmentStatusPlanned   AssignmentStatus = "PLANNED"   // Future assignment in rotation
+	AssignmentStatusPending       AssignmentStatus = "PENDING"        // Current, needs to be done
+	AssignmentStatusCompleted     AssignmentStatus = "COMPLETED"      // Done
+	AssignmentStatusOverdue       AssignmentStatus = "OVERDUE"        // Past due date
+	AssignmentStatusPlanned       AssignmentStatus = "PLANNED"        // Future assignment in rotation
+	AssignmentStatusCancelled     AssignmentStatus = "CANCELLED"      // Dropped because its chore was archived or rescheduled
+	AssignmentStatusPendingReview AssignmentStatus = "PENDING_REVIEW" // Done, waiting for another member to approve
 )
 
 type AccountChore struct {
-	ID            uuid.UUID        `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
-	ChoreID       uuid.UUID        `gorm:"not null" json:"choreId"`
-	AccountID     uuid.UUID        `gorm:"not null" json:"accountId"`
-	HouseholdID   uuid.UUID        `gorm:"not null" json:"householdId"`
-	DueDate       time.Time        `gorm:"not null" json:"dueDate"`
-	CompletedAt   *time.Time       `json:"completedAt"`
-	Status        AssignmentStatus `gorm:"not null; default:'PENDING'" json:"status"`
-	RotationOrder int              `gorm:"not null" json:"rotationOrder"`
-	Chore         Chore            `gorm:"foreignKey:ChoreID"`
-	Account       Account          `gorm:"foreignKey:AccountID"`
-	Household     Household        `gorm:"foreignKey:HouseholdID"`
-	Points        int              `gorm:"not null" json:"points"`
+	ID              uuid.UUID        `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	ChoreID         uuid.UUID        `gorm:"not null" json:"choreId"`
+	AccountID       uuid.UUID        `gorm:"not null" json:"accountId"`
+	HouseholdID     uuid.UUID        `gorm:"not null" json:"householdId"`
+	DueDate         time.Time        `gorm:"not null" json:"dueDate"`
+	CompletedAt     *time.Time       `json:"completedAt"`
+	Status          AssignmentStatus `gorm:"not null; default:'PENDING'" json:"status"`
+	RotationOrder   int              `gorm:"not null" json:"rotationOrder"`
+	CoveredForID    *uuid.UUID       `gorm:"index" json:"coveredForId"`       // Whose turn it was when they were away
+	RejectionReason string           `gorm:"size:500" json:"rejectionReason"` // Why the last completion was rejected, until one is approved
+	Chore           Chore            `gorm:"foreignKey:ChoreID"`
+	Account         Account          `gorm:"foreignKey:AccountID"`
+	Household       Household        `gorm:"foreignKey:HouseholdID"`
+	Points          int              `gorm:"not null" json:"points"`
 }
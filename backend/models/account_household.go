@@ -6,10 +6,58 @@ import (
 	"github.com/google/uuid"
 )
 
+type HouseholdRole string
+
+const (
+	HouseholdRoleOwner  HouseholdRole = "OWNER"
+	HouseholdRoleAdmin  HouseholdRole = "ADMIN"
+	HouseholdRoleMember HouseholdRole = "MEMBER"
+)
+
+type HouseholdPermission string
+
+const (
+	PermissionManageChores    HouseholdPermission = "MANAGE_CHORES"    // Edit and delete chores
+	PermissionManageMembers   HouseholdPermission = "MANAGE_MEMBERS"   // Remove members
+	PermissionManageHousehold HouseholdPermission = "MANAGE_HOUSEHOLD" // Change household settings
+	PermissionManageInvites   HouseholdPermission = "MANAGE_INVITES"   // Create, list and revoke invites
+	PermissionManageRoles     HouseholdPermission = "MANAGE_ROLES"     // Promote/demote admins, transfer ownership
+	PermissionDeleteHousehold HouseholdPermission = "DELETE_HOUSEHOLD" // Archive the whole household
+)
+
+var rolePermissions = map[HouseholdRole][]HouseholdPermission{
+	HouseholdRoleOwner: {
+		PermissionManageChores,
+		PermissionManageMembers,
+		PermissionManageHousehold,
+		PermissionManageInvites,
+		PermissionManageRoles,
+		PermissionDeleteHousehold,
+	},
+	HouseholdRoleAdmin: {
+		PermissionManageChores,
+		PermissionManageMembers,
+		PermissionManageHousehold,
+		PermissionManageInvites,
+	},
+	HouseholdRoleMember: {},
+}
+
+// Can reports whether the role grants the permission.
+func (r HouseholdRole) Can(permission HouseholdPermission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
 type AccountHousehold struct {
 	ID         uuid.UUID `gorm:"primaryKey; default:gen_random_uuid()" json:"id"`
 	AccountID  uuid.UUID `gorm:"type:uuid;primary_key"`
 	HouseholdID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Role        HouseholdRole `gorm:"not null; default:'MEMBER'" json:"role"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	Account     Account   `gorm:"foreignKey:AccountID"`
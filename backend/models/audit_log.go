@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionChoreCreated         AuditAction = "CHORE_CREATED"
+	AuditActionChoreUpdated         AuditAction = "CHORE_UPDATED"
+	AuditActionChoreArchived        AuditAction = "CHORE_ARCHIVED"
+	AuditActionChoreRestored        AuditAction = "CHORE_RESTORED"
+	AuditActionChoreCompleted       AuditAction = "CHORE_COMPLETED"
+	AuditActionChoreApproved        AuditAction = "CHORE_APPROVED"
+	AuditActionChoreRejected        AuditAction = "CHORE_REJECTED"
+	AuditActionCompletionUndone     AuditAction = "CHORE_COMPLETION_UNDONE"
+	AuditActionChecklistItemChecked AuditAction = "CHECKLIST_ITEM_CHECKED"
+	AuditActionAssignmentCreated    AuditAction = "ASSIGNMENT_CREATED"
+	AuditActionAssignmentReassigned AuditAction = "ASSIGNMENT_REASSIGNED"
+	AuditActionSwapRequested        AuditAction = "SWAP_REQUESTED"
+	AuditActionSwapAccepted         AuditAction = "SWAP_ACCEPTED"
+	AuditActionSwapDeclined         AuditAction = "SWAP_DECLINED"
+	AuditActionSwapCancelled        AuditAction = "SWAP_CANCELLED"
+	AuditActionSwapExpired          AuditAction = "SWAP_EXPIRED"
+	AuditActionAssignmentOverdue    AuditAction = "ASSIGNMENT_OVERDUE"
+	AuditActionTransactionCreated   AuditAction = "TRANSACTION_CREATED"
+	AuditActionSplitSettled         AuditAction = "SPLIT_SETTLED"
+	AuditActionReviewCreated        AuditAction = "REVIEW_CREATED"
+	AuditActionHouseholdCreated     AuditAction = "HOUSEHOLD_CREATED"
+	AuditActionHouseholdUpdated     AuditAction = "HOUSEHOLD_UPDATED"
+	AuditActionHouseholdDeleted     AuditAction = "HOUSEHOLD_DELETED"
+	AuditActionMemberJoined         AuditAction = "MEMBER_JOINED"
+	AuditActionMemberLeft           AuditAction = "MEMBER_LEFT"
+	AuditActionMemberRemoved        AuditAction = "MEMBER_REMOVED"
+	AuditActionMemberRoleChanged    AuditAction = "MEMBER_ROLE_CHANGED"
+	AuditActionMemberProfileUpdated AuditAction = "MEMBER_PROFILE_UPDATED"
+	AuditActionAccountDeleted       AuditAction = "ACCOUNT_DELETED"
+	AuditActionOwnershipTransferred AuditAction = "OWNERSHIP_TRANSFERRED"
+	AuditActionInviteCreated        AuditAction = "INVITE_CREATED"
+	AuditActionInviteRevoked        AuditAction = "INVITE_REVOKED"
+	AuditActionAccessTokenCreated   AuditAction = "ACCESS_TOKEN_CREATED"
+	AuditActionAccessTokenRevoked   AuditAction = "ACCESS_TOKEN_REVOKED"
+	AuditActionAvailabilityCreated  AuditAction = "AVAILABILITY_CREATED"
+	AuditActionAvailabilityDeleted  AuditAction = "AVAILABILITY_DELETED"
+	AuditActionTemplateCreated      AuditAction = "TEMPLATE_CREATED"
+	AuditActionTemplateDeleted      AuditAction = "TEMPLATE_DELETED"
+)
+
+type AuditEntityType string
+
+const (
+	AuditEntityChore               AuditEntityType = "CHORE"
+	AuditEntityAccountChore        AuditEntityType = "ACCOUNT_CHORE"
+	AuditEntityChecklistItem       AuditEntityType = "CHECKLIST_ITEM"
+	AuditEntityTransaction         AuditEntityType = "TRANSACTION"
+	AuditEntityTransactionSplit    AuditEntityType = "TRANSACTION_SPLIT"
+	AuditEntityChoreReview         AuditEntityType = "CHORE_REVIEW"
+	AuditEntityChoreSwap           AuditEntityType = "CHORE_SWAP"
+	AuditEntityMemberAvailability  AuditEntityType = "MEMBER_AVAILABILITY"
+	AuditEntityChoreTemplate       AuditEntityType = "CHORE_TEMPLATE"
+	AuditEntityHousehold           AuditEntityType = "HOUSEHOLD"
+	AuditEntityHouseholdMember     AuditEntityType = "HOUSEHOLD_MEMBER"
+	AuditEntityHouseholdInvite     AuditEntityType = "HOUSEHOLD_INVITE"
+	AuditEntityPersonalAccessToken AuditEntityType = "PERSONAL_ACCESS_TOKEN"
+)
+
+// AuditLog is an append-only record of a change made in a household. Before
+// and After hold JSON snapshots of the entity, either of which is null when the
+// entity was created or removed. ActorID is nil for changes made by the system.
+type AuditLog struct {
+	ID          uuid.UUID       `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	HouseholdID uuid.UUID       `gorm:"not null; index" json:"householdId"`
+	ActorID     *uuid.UUID      `gorm:"index" json:"actorId"`
+	Action      AuditAction     `gorm:"not null; size:64; index" json:"action"`
+	EntityType  AuditEntityType `gorm:"not null; size:64" json:"entityType"`
+	EntityID    uuid.UUID       `gorm:"not null; index" json:"entityId"`
+	Before      json.RawMessage `gorm:"type:jsonb" json:"before"`
+	After       json.RawMessage `gorm:"type:jsonb" json:"after"`
+	CreatedAt   time.Time       `gorm:"not null; default:CURRENT_TIMESTAMP; index" json:"createdAt"`
+	Actor       Account         `gorm:"foreignKey:ActorID" json:"actor"`
+}
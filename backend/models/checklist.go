@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChecklistItem is one step of a chore. Removed items are kept so the check
+// state and reviews that reference them still make sense.
+type ChecklistItem struct {
+	ID        uuid.UUID  `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	ChoreID   uuid.UUID  `gorm:"not null; index" json:"choreId"`
+	Title     string     `gorm:"not null; size:255" json:"title"`
+	Position  int        `gorm:"not null" json:"position"`
+	RemovedAt *time.Time `json:"removedAt"`
+	CreatedAt time.Time  `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	Chore     Chore      `gorm:"foreignKey:ChoreID" json:"chore"`
+}
+
+// AssignmentChecklistItem is the check state of an item for one assignment.
+// Items without a row are unchecked.
+type AssignmentChecklistItem struct {
+	ID              uuid.UUID     `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	AccountChoreID  uuid.UUID     `gorm:"not null; uniqueIndex:idx_assignment_checklist_item" json:"accountChoreId"`
+	ChecklistItemID uuid.UUID     `gorm:"not null; uniqueIndex:idx_assignment_checklist_item" json:"checklistItemId"`
+	Checked         bool          `gorm:"not null; default:false" json:"checked"`
+	CheckedAt       *time.Time    `json:"checkedAt"`
+	CheckedByID     *uuid.UUID    `json:"checkedById"`
+	AccountChore    AccountChore  `gorm:"foreignKey:AccountChoreID" json:"accountChore"`
+	ChecklistItem   ChecklistItem `gorm:"foreignKey:ChecklistItemID" json:"checklistItem"`
+}
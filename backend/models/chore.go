@@ -8,6 +8,7 @@ import (
 
 type ChoreType string
 type FrequencyType string
+type AssignmentStrategy string
 
 const (
 	ChoreTypeOneTime    ChoreType = "ONE_TIME"
@@ -15,8 +16,24 @@ const (
 
 	FrequencyTypeDaily    FrequencyType = "DAILY"
 	FrequencyTypeWeekly  FrequencyType = "WEEKLY"
+	FrequencyTypeMonthly FrequencyType = "MONTHLY"
+
+	// How a recurring chore picks the assignee of each occurrence
+	StrategyRoundRobin  AssignmentStrategy = "ROUND_ROBIN"  // Take turns in rotation order
+	StrategyLeastPoints AssignmentStrategy = "LEAST_POINTS" // Whoever has the fewest points in the household lately
+	StrategyLeastEffort AssignmentStrategy = "LEAST_EFFORT" // Whoever has the fewest estimated minutes lately
+	StrategyRandomFair  AssignmentStrategy = "RANDOM_FAIR"  // Random, among those with the fewest turns lately
+	StrategyFixed       AssignmentStrategy = "FIXED"        // Always the first assignee
 )
 
+var AssignmentStrategies = []AssignmentStrategy{
+	StrategyRoundRobin,
+	StrategyLeastPoints,
+	StrategyLeastEffort,
+	StrategyRandomFair,
+	StrategyFixed,
+}
+
 type Chore struct {
 	ID            uuid.UUID    `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
 	Title         string       `gorm:"not null; size:255" json:"title"`
@@ -25,8 +42,16 @@ type Chore struct {
 	Type          ChoreType    `gorm:"not null" json:"type"`
 	EndDate       time.Time   `json:"endDate"`    
 	FrequencyType *FrequencyType `json:"frequencyType"`
+	Recurrence    string       `gorm:"size:255" json:"recurrence"` // RRULE for recurring chores
+	RecurrenceStart *time.Time `json:"recurrenceStart"`             // Anchor for INTERVAL and COUNT
+	ArchivedAt    *time.Time   `gorm:"index" json:"archivedAt"`    // Archived chores plan no assignments until restored
 	CreatedAt     time.Time    `gorm:"not null; default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt     time.Time    `gorm:"not null; default:CURRENT_TIMESTAMP" json:"updated_at"`
 	Household     Household    `gorm:"foreignKey:HouseholdID" json:"household"`
 	Points        int          `gorm:"not null" json:"points"`
+	EstimatedMinutes int       `gorm:"not null; default:0" json:"estimatedMinutes"`
+	Strategy      AssignmentStrategy `gorm:"not null; size:32; default:'ROUND_ROBIN'" json:"strategy"`
+	RequireChecklist bool      `gorm:"not null; default:false" json:"requireChecklist"` // Every checklist item must be checked to complete
+	RequireReview bool         `gorm:"not null; default:false" json:"requireReview"`    // Completions count once another member approves them
+	Checklist     []ChecklistItem `gorm:"foreignKey:ChoreID" json:"checklist"`
 }
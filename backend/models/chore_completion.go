@@ -0,0 +1,24 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChoreCompletion remembers what completing an assignment changed, so the
+// completion can be undone within the grace window or reverted when a review
+// rejects it. Effects holds the prior state of the assignments the rotation
+// created or moved.
+type ChoreCompletion struct {
+	ID             uuid.UUID        `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	AccountChoreID uuid.UUID        `gorm:"not null; index" json:"accountChoreId"`
+	HouseholdID    uuid.UUID        `gorm:"not null" json:"householdId"`
+	CompletedByID  uuid.UUID        `gorm:"not null" json:"completedById"`
+	CompletedAt    time.Time        `gorm:"not null" json:"completedAt"`
+	PreviousStatus AssignmentStatus `gorm:"not null" json:"previousStatus"`
+	Effects        json.RawMessage  `gorm:"type:jsonb" json:"effects"`
+	UndoneAt       *time.Time       `json:"undoneAt"`
+	AccountChore   AccountChore     `gorm:"foreignKey:AccountChoreID" json:"accountChore"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChoreDependency makes a chore wait for another. Each occurrence of the chore
+// is blocked until the prerequisite's latest occurrence due on or before it is done.
+type ChoreDependency struct {
+	ID             uuid.UUID `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	ChoreID        uuid.UUID `gorm:"not null; uniqueIndex:idx_chore_dependency" json:"choreId"`
+	PrerequisiteID uuid.UUID `gorm:"not null; uniqueIndex:idx_chore_dependency; index" json:"prerequisiteId"`
+	CreatedAt      time.Time `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	Chore          Chore     `gorm:"foreignKey:ChoreID" json:"chore"`
+	Prerequisite   Chore     `gorm:"foreignKey:PrerequisiteID" json:"prerequisite"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChorePhoto is proof of completion attached to an assignment. The keys point
+// into blob storage; clients only ever get signed URLs for them.
+type ChorePhoto struct {
+	ID             uuid.UUID    `gorm:"primaryKey; type:uuid" json:"id"`
+	AccountChoreID uuid.UUID    `gorm:"not null; index" json:"accountChoreId"`
+	CompletionID   *uuid.UUID   `gorm:"index" json:"completionId"`
+	HouseholdID    uuid.UUID    `gorm:"not null" json:"householdId"`
+	UploadedByID   uuid.UUID    `gorm:"not null" json:"uploadedById"`
+	Key            string       `gorm:"not null" json:"-"`
+	ThumbnailKey   string       `gorm:"not null" json:"-"`
+	ContentType    string       `gorm:"not null" json:"contentType"`
+	Size           int          `gorm:"not null" json:"size"`
+	Width          int          `gorm:"not null" json:"width"`
+	Height         int          `gorm:"not null" json:"height"`
+	CreatedAt      time.Time    `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	AccountChore   AccountChore `gorm:"foreignKey:AccountChoreID" json:"accountChore"`
+}
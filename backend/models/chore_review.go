@@ -6,15 +6,24 @@ import (
 	"github.com/google/uuid"
 )
 
+type ReviewStatus string
+
+const (
+	ReviewStatusApproved ReviewStatus = "APPROVED"
+	ReviewStatusRejected ReviewStatus = "REJECTED" // Reopens an assignment that was waiting for review
+)
+
 type ChoreReview struct {
 	ID               uuid.UUID    `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
-	AccountChoreID   uuid.UUID    `gorm:"not null" json:"choreId"`
+	AccountChoreID   uuid.UUID    `gorm:"not null; index" json:"choreId"`
 	HouseholdID      uuid.UUID    `gorm:"not null" json:"householdId"`
 	ReviewerID       uuid.UUID    `gorm:"not null" json:"reviewerId"`
 	AccountChore     AccountChore `gorm:"foreignKey:AccountChoreID" json:"chore"`
 	Reviewer         Account      `gorm:"foreignKey:ReviewerID" json:"reviewer"`
 	Review           string       `gorm:"not null" json:"review"`
-	ReviewerStatus   string       `gorm:"not null" json:"reviewerStatus"`
+	ReviewerStatus   ReviewStatus `gorm:"not null" json:"reviewerStatus"`
+	Rating           *int         `json:"rating"` // Optional, 1-5
 	CreatedAt        time.Time    `gorm:"default: now()" json:"createdAt"`
 	Household        Household    `gorm:"foreignKey:HouseholdID" json:"household"`
+	MissedItems      []ChecklistItem `gorm:"many2many:review_missed_items;" json:"missedItems"`
 }
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SwapStatus string
+
+const (
+	SwapStatusPending   SwapStatus = "PENDING"   // Waiting for the recipient
+	SwapStatusAccepted  SwapStatus = "ACCEPTED"  // Assignments were exchanged
+	SwapStatusDeclined  SwapStatus = "DECLINED"  // Turned down by the recipient
+	SwapStatusCancelled SwapStatus = "CANCELLED" // Withdrawn by the requester or no longer possible
+	SwapStatusExpired   SwapStatus = "EXPIRED"   // Nobody answered in time
+)
+
+// ChoreSwap asks another member to trade assignments. Without a requested
+// assignment it is a one-way handoff of the offered one.
+type ChoreSwap struct {
+	ID                    uuid.UUID     `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	HouseholdID           uuid.UUID     `gorm:"not null; index" json:"householdId"`
+	RequesterID           uuid.UUID     `gorm:"not null" json:"requesterId"`
+	RecipientID           uuid.UUID     `gorm:"not null" json:"recipientId"`
+	OfferedAssignmentID   uuid.UUID     `gorm:"not null" json:"offeredAssignmentId"`
+	RequestedAssignmentID *uuid.UUID    `json:"requestedAssignmentId"`
+	Message               string        `gorm:"size:500" json:"message"`
+	Status                SwapStatus    `gorm:"not null; default:'PENDING'; index" json:"status"`
+	ExpiresAt             time.Time     `gorm:"not null" json:"expiresAt"`
+	RespondedAt           *time.Time    `json:"respondedAt"`
+	CreatedAt             time.Time     `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	Household             Household     `gorm:"foreignKey:HouseholdID" json:"household"`
+	Requester             Account       `gorm:"foreignKey:RequesterID" json:"requester"`
+	Recipient             Account       `gorm:"foreignKey:RecipientID" json:"recipient"`
+	OfferedAssignment     AccountChore  `gorm:"foreignKey:OfferedAssignmentID" json:"offeredAssignment"`
+	RequestedAssignment   *AccountChore `gorm:"foreignKey:RequestedAssignmentID" json:"requestedAssignment"`
+}
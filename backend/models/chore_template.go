@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChoreTemplate is a reusable chore definition. Shared templates can also be
+// used in the other households of anyone who belongs to the owning household.
+type ChoreTemplate struct {
+	ID               uuid.UUID      `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	HouseholdID      uuid.UUID      `gorm:"not null; index" json:"householdId"`
+	CreatedByID      uuid.UUID      `gorm:"not null" json:"createdById"`
+	Title            string         `gorm:"not null; size:255" json:"title"`
+	Description      string         `json:"description"`
+	Category         string         `gorm:"size:64" json:"category"`
+	Type             ChoreType      `gorm:"not null" json:"type"`
+	FrequencyType    *FrequencyType `json:"frequencyType"`
+	Schedule         string         `gorm:"size:32" json:"schedule"`    // Comma-separated days of the week, 1-7 for Monday-Sunday
+	Recurrence       string         `gorm:"size:255" json:"recurrence"` // RRULE, takes precedence over the schedule
+	Points           int            `gorm:"not null" json:"points"`
+	EstimatedMinutes int            `gorm:"not null; default:0" json:"estimatedMinutes"`
+	Shared           bool           `gorm:"not null; default:false" json:"shared"`
+	CreatedAt        time.Time      `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	Household        Household      `gorm:"foreignKey:HouseholdID" json:"household"`
+	CreatedBy        Account        `gorm:"foreignKey:CreatedByID" json:"createdBy"`
+}
+
+func (t ChoreTemplate) ScheduleDays() []int {
+	var days []int
+	for _, day := range strings.Split(t.Schedule, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(day)); err == nil {
+			days = append(days, n)
+		}
+	}
+	return days
+}
+
+func (t *ChoreTemplate) SetScheduleDays(days []int) {
+	parts := make([]string, len(days))
+	for i, day := range days {
+		parts[i] = strconv.Itoa(day)
+	}
+	t.Schedule = strings.Join(parts, ",")
+}
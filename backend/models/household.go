@@ -4,13 +4,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Household struct {
 	ID        uuid.UUID    `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
 	Password  string    `gorm:"not null; size:255" json:"password"`
 	Name      string    `gorm:"not null; size:255" json:"name"`
+	Timezone  string    `gorm:"not null; size:64; default:'UTC'" json:"timezone"` // IANA name, day boundaries are computed in it
 	CreatedAt time.Time `gorm:"not null; default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time `gorm:"not null; default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 	Members   []Account `gorm:"many2many:account_households;"`
 }
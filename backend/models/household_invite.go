@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HouseholdInvite struct {
+	ID          uuid.UUID  `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	HouseholdID uuid.UUID  `gorm:"not null; index" json:"householdId"`
+	CreatedByID uuid.UUID  `gorm:"not null" json:"createdById"`
+	Code        string     `gorm:"not null; uniqueIndex; size:16" json:"code"`
+	Email       *string    `gorm:"size:255" json:"email"`
+	MaxUses     int        `gorm:"not null; default:1" json:"maxUses"`
+	UseCount    int        `gorm:"not null; default:0" json:"useCount"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt   *time.Time `json:"revokedAt"`
+	CreatedAt   time.Time  `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	Household   Household  `gorm:"foreignKey:HouseholdID" json:"household"`
+	CreatedBy   Account    `gorm:"foreignKey:CreatedByID" json:"createdBy"`
+}
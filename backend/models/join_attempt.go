@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JoinAttempt records each password attempt against JoinHousehold so repeated
+// failures can be throttled and reviewed by household admins.
+type JoinAttempt struct {
+	ID          uuid.UUID `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	HouseholdID uuid.UUID `gorm:"not null; index" json:"householdId"`
+	AccountID   uuid.UUID `gorm:"not null; index" json:"accountId"`
+	Succeeded   bool      `gorm:"not null" json:"succeeded"`
+	IPAddress   string    `gorm:"size:64" json:"ipAddress"`
+	CreatedAt   time.Time `gorm:"not null; default:CURRENT_TIMESTAMP; index" json:"createdAt"`
+	Account     Account   `gorm:"foreignKey:AccountID" json:"account"`
+}
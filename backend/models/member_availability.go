@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemberAvailability is a range of days, inclusive and in the household's
+// timezone, during which a member should not be given chores.
+type MemberAvailability struct {
+	ID          uuid.UUID `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	HouseholdID uuid.UUID `gorm:"not null; index" json:"householdId"`
+	AccountID   uuid.UUID `gorm:"not null; index" json:"accountId"`
+	StartDate   time.Time `gorm:"not null; type:date" json:"startDate"`
+	EndDate     time.Time `gorm:"not null; type:date" json:"endDate"`
+	Note        string    `gorm:"size:255" json:"note"`
+	CreatedAt   time.Time `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	Household   Household `gorm:"foreignKey:HouseholdID" json:"household"`
+	Account     Account   `gorm:"foreignKey:AccountID" json:"account"`
+}
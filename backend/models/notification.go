@@ -11,9 +11,26 @@ const (
 	NotificationActionChoreAssigned    = "CHORE_ASSIGNED"
 	NotificationActionChorePending     = "CHORE_PENDING"
 	NotificationActionChoreCompleted   = "CHORE_COMPLETED"
+	NotificationActionChoreOverdue     = "CHORE_OVERDUE"
+	NotificationActionChoreUpdated     = "CHORE_UPDATED"
+	NotificationActionChoreArchived    = "CHORE_ARCHIVED"
+	NotificationActionChoreAwaitingReview = "CHORE_AWAITING_REVIEW"
+	NotificationActionChoreApproved    = "CHORE_APPROVED"
+	NotificationActionChoreRejected    = "CHORE_REJECTED"
+	NotificationActionChoreUnblocked   = "CHORE_UNBLOCKED"
+	NotificationActionCompletionUndone = "CHORE_COMPLETION_UNDONE"
 	NotificationActionTransactionAdded = "TRANSACTION_ADDED"
 	NotificationActionReviewSubmitted  = "REVIEW_SUBMITTED"
 	NotificationActionTransactionSettled = "TRANSACTION_SETTLED"
+	NotificationActionMemberJoined     = "MEMBER_JOINED"
+	NotificationActionMemberLeft       = "MEMBER_LEFT"
+	NotificationActionMemberRemoved    = "MEMBER_REMOVED"
+	NotificationActionHouseholdDeleted = "HOUSEHOLD_DELETED"
+	NotificationActionSwapRequested    = "SWAP_REQUESTED"
+	NotificationActionSwapAccepted     = "SWAP_ACCEPTED"
+	NotificationActionSwapDeclined     = "SWAP_DECLINED"
+	NotificationActionSwapCancelled    = "SWAP_CANCELLED"
+	NotificationActionSwapExpired      = "SWAP_EXPIRED"
 )
 
 type Notification struct {
@@ -25,6 +42,8 @@ type Notification struct {
 	TransactionID    *uuid.UUID   		`json:"transactionId"`
 	ReviewID         *uuid.UUID   		`json:"reviewId"`
 	SplitID          *uuid.UUID   		`json:"splitId"`
+	TargetAccountID  *uuid.UUID   		`json:"targetAccountId"`
+	SwapID           *uuid.UUID   		`json:"swapId"`
 	HouseholdID      uuid.UUID    		`json:"householdId"`
 	Account          Account      		`gorm:"foreignKey:AccountID" json:"actorAccount"`
 	AccountChore     AccountChore 		`gorm:"foreignKey:AccountChoreID" json:"accountChore"`
@@ -32,6 +51,10 @@ type Notification struct {
 	Transaction      Transaction   		`gorm:"foreignKey:TransactionID" json:"transaction"`
 	Review           ChoreReview   		`gorm:"foreignKey:ReviewID" json:"review"`
 	CreatedAt        time.Time     		`gorm:"default: now()" json:"createdAt"`
+	RetractedAt      *time.Time    		`json:"retractedAt"` // Set when the completion it announced was undone
+	CompletionID     *uuid.UUID    		`gorm:"index" json:"completionId"` // The completion that sent it, if any
 	Household        Household     		`gorm:"foreignKey:HouseholdID" json:"household"`
 	Split            TransactionSplit 	`gorm:"foreignKey:SplitID" json:"split"`
+	TargetAccount    Account      		`gorm:"foreignKey:TargetAccountID" json:"targetAccount"`
+	Swap             ChoreSwap    		`gorm:"foreignKey:SwapID" json:"swap"`
 }
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenScope string
+
+const (
+	TokenScopeChoresRead         TokenScope = "chores:read"
+	TokenScopeChoresCreate       TokenScope = "chores:create"
+	TokenScopeChoresComplete     TokenScope = "chores:complete"
+	TokenScopeTransactionsRead   TokenScope = "transactions:read"
+	TokenScopeTransactionsCreate TokenScope = "transactions:create"
+)
+
+var TokenScopes = []TokenScope{
+	TokenScopeChoresRead,
+	TokenScopeChoresCreate,
+	TokenScopeChoresComplete,
+	TokenScopeTransactionsRead,
+	TokenScopeTransactionsCreate,
+}
+
+// PersonalAccessToken lets scripts act as an account within one household.
+// Only a hash of the token is stored.
+type PersonalAccessToken struct {
+	ID          uuid.UUID  `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	AccountID   uuid.UUID  `gorm:"not null; index" json:"accountId"`
+	HouseholdID uuid.UUID  `gorm:"not null" json:"householdId"`
+	Name        string     `gorm:"not null; size:255" json:"name"`
+	TokenHash   string     `gorm:"not null; uniqueIndex" json:"-"`
+	Prefix      string     `gorm:"not null; size:16" json:"prefix"`
+	Scopes      string     `gorm:"not null" json:"scopes"` // Comma-separated TokenScope values
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	RevokedAt   *time.Time `json:"revokedAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	CreatedAt   time.Time  `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	Account     Account    `gorm:"foreignKey:AccountID"`
+	Household   Household  `gorm:"foreignKey:HouseholdID"`
+}
+
+func (t PersonalAccessToken) ScopeList() []TokenScope {
+	var scopes []TokenScope
+	for _, scope := range strings.Split(t.Scopes, ",") {
+		if scope != "" {
+			scopes = append(scopes, TokenScope(scope))
+		}
+	}
+	return scopes
+}
+
+func (t PersonalAccessToken) HasScope(scope TokenScope) bool {
+	for _, s := range t.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
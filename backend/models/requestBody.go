@@ -4,20 +4,23 @@ import (
 	"time"
 )
 
-type CreateAccountRequestBody struct {
-	GoogleId    string `json:"google_id"`
-	Name string `json:"name"`
+type GoogleSignInRequestBody struct {
+	IDToken string `json:"idToken" binding:"required"`
+}
+
+type RefreshSessionRequestBody struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
 }
 
 type CreateHouseholdRequestBody struct {
 	Name string `json:"name"`
 	Password string `json:"password"`
+	Timezone string `json:"timezone"` // Defaults to the creator's timezone
 }
 
 type JoinHouseholdRequestBody struct {
 	HouseholdID string `json:"householdId" binding:"required"`
 	Password    string `json:"password" binding:"required"`
-	AccountID   string `json:"accountId" binding:"required"`
 }
 
 type CreateChoreRequestBody struct {
@@ -27,8 +30,64 @@ type CreateChoreRequestBody struct {
 	EndDate      time.Time `json:"endDate"`
 	Frequency    string    `json:"frequency"`
 	Schedule     []int     `json:"schedule"` // Days of week for recurring
+	Recurrence   *RecurrenceRequestBody `json:"recurrence"` // Takes precedence over frequency and schedule
 	AssigneeIDs  []string  `json:"assigneeIds" binding:"required"`
 	Points       int       `json:"points" binding:"required"`
+	EstimatedMinutes int   `json:"estimatedMinutes"`
+	Strategy     string    `json:"strategy"` // Defaults to ROUND_ROBIN
+	Checklist    []string  `json:"checklist"`
+	RequireChecklist bool  `json:"requireChecklist"`
+	RequireReview bool     `json:"requireReview"`
+}
+
+// UpdateChoreRequestBody changes a chore. Omitted fields are left as they are;
+// schedule, recurrence and assigneeIds replace the current values when sent.
+type UpdateChoreRequestBody struct {
+	Title       *string                `json:"title"`
+	Description *string                `json:"description"`
+	Points      *int                   `json:"points"`
+	EndDate     *time.Time             `json:"endDate"`
+	Frequency   *string                `json:"frequency"`
+	Schedule    []int                  `json:"schedule"`
+	Recurrence  *RecurrenceRequestBody `json:"recurrence"`
+	AssigneeIDs []string               `json:"assigneeIds"`
+	EstimatedMinutes *int              `json:"estimatedMinutes"`
+	Strategy    *string                `json:"strategy"`
+	RequireReview *bool                `json:"requireReview"`
+}
+
+// RecurrenceRequestBody describes a recurring chore's schedule. RRule, when
+// set, is used as-is; otherwise the rule is built from the other fields.
+type RecurrenceRequestBody struct {
+	Frequency   string     `json:"frequency"`   // DAILY, WEEKLY or MONTHLY
+	Interval    int        `json:"interval"`    // Every N days, weeks or months
+	DaysOfWeek  []int      `json:"daysOfWeek"`  // 1-7 for Monday-Sunday
+	DayOfMonth  int        `json:"dayOfMonth"`  // Monthly by date, negative counts from the end
+	WeekOfMonth int        `json:"weekOfMonth"` // Monthly by weekday, e.g. 1 with daysOfWeek [6] for the first Saturday
+	RRule       string     `json:"rrule"`
+	StartDate   *time.Time `json:"startDate"`
+}
+
+type CreateChoreTemplateRequestBody struct {
+	Title            string                 `json:"title" binding:"required"`
+	Description      string                 `json:"description"`
+	Category         string                 `json:"category"`
+	Type             string                 `json:"type" binding:"required"`
+	Frequency        string                 `json:"frequency"`
+	Schedule         []int                  `json:"schedule"`
+	Recurrence       *RecurrenceRequestBody `json:"recurrence"`
+	Points           int                    `json:"points"`
+	EstimatedMinutes int                    `json:"estimatedMinutes"`
+	Shared           bool                   `json:"shared"` // Usable in the creator's other households
+}
+
+// InstantiateChoreTemplateRequestBody creates a chore from a template. endDate
+// is required for one-time templates.
+type InstantiateChoreTemplateRequestBody struct {
+	AssigneeIDs []string   `json:"assigneeIds" binding:"required"`
+	EndDate     time.Time  `json:"endDate"`
+	StartDate   *time.Time `json:"startDate"`
+	Strategy    string     `json:"strategy"`
 }
 
 type CreateTransactionRequestBody struct {
@@ -37,7 +96,79 @@ type CreateTransactionRequestBody struct {
 	SpentAt       time.Time `json:"spentAt"`
 }
 
+// UpdateChoreDependenciesRequestBody replaces the chores a chore waits for.
+// An empty list removes every dependency.
+type UpdateChoreDependenciesRequestBody struct {
+	PrerequisiteIDs []string `json:"prerequisiteIds"`
+}
+
 type CreateChoreReviewRequestBody struct {
-	ReviewerStatus 	string `json:"reviewerStatus" binding:"required"`
+	ReviewerStatus 	string `json:"reviewerStatus" binding:"required"` // APPROVED or REJECTED
 	ReviewerComment string `json:"reviewerComment"`
+	Rating          *int   `json:"rating"` // Optional, 1-5
+	MissedItemIDs   []string `json:"missedItemIds"` // Checklist items the reviewer found skipped
+}
+
+// UpdateChecklistRequestBody replaces a chore's checklist. Items with an id are
+// kept and renamed or reordered, items without one are added and any current
+// item left out is removed.
+type UpdateChecklistRequestBody struct {
+	Items []struct {
+		ID    *string `json:"id"`
+		Title string  `json:"title" binding:"required"`
+	} `json:"items"`
+	RequireAll *bool `json:"requireAll"`
+}
+
+type CheckChecklistItemRequestBody struct {
+	Checked bool `json:"checked"`
+}
+
+type CreateHouseholdInviteRequestBody struct {
+	ExpiresInHours int    `json:"expiresInHours"`
+	MaxUses        int    `json:"maxUses"`
+	Email          string `json:"email"`
+}
+
+type CreateChoreSwapRequestBody struct {
+	OfferedAssignmentID   string  `json:"offeredAssignmentId" binding:"required"`
+	RecipientID           string  `json:"recipientId" binding:"required"`
+	RequestedAssignmentID *string `json:"requestedAssignmentId"` // Omit for a one-way handoff
+	Message               string  `json:"message"`
+	ExpiresInHours        int     `json:"expiresInHours"`
+}
+
+// CreateAvailabilityRequestBody takes dates as YYYY-MM-DD in the household's
+// timezone, both days included.
+type CreateAvailabilityRequestBody struct {
+	StartDate string `json:"startDate" binding:"required"`
+	EndDate   string `json:"endDate" binding:"required"`
+	Note      string `json:"note"`
+}
+
+type UpdateMemberRoleRequestBody struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type TransferOwnershipRequestBody struct {
+	AccountID string `json:"accountId" binding:"required"`
+}
+
+type UpdateAccountRequestBody struct {
+	Name      *string `json:"name"`
+	AvatarURL *string `json:"avatarUrl"`
+	Timezone  *string `json:"timezone"`
+}
+
+type UpdateHouseholdRequestBody struct {
+	Name     *string `json:"name"`
+	Password *string `json:"password"`
+	Timezone *string `json:"timezone"`
+}
+
+type CreatePersonalAccessTokenRequestBody struct {
+	Name          string   `json:"name" binding:"required"`
+	HouseholdID   string   `json:"householdId" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expiresInDays"`
 }
@@ -1,15 +1,26 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type AccountResponse struct {
-	ID       uuid.UUID `json:"id"`
-	Name     string    `json:"name"`
-	GoogleId string    `json:"googleId"`
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	GoogleId  string    `json:"googleId"`
+	Email     string    `json:"email"`
+	AvatarURL string    `json:"avatarUrl"`
+	Timezone  string    `json:"timezone"`
+}
+
+type SessionResponse struct {
+	AccessToken          string          `json:"accessToken"`
+	AccessTokenExpiresAt time.Time       `json:"accessTokenExpiresAt"`
+	RefreshToken         string          `json:"refreshToken"`
+	Account              AccountResponse `json:"account"`
 }
 
 type ChoreResponse struct {
@@ -17,8 +28,13 @@ type ChoreResponse struct {
 	Title       string       `json:"title"`
 	Description string       `json:"description"`
 	Type        ChoreType    `json:"type"`
+	Recurrence  string       `json:"recurrence,omitempty"`
 	HouseholdID uuid.UUID    `json:"householdId"`
 	CreatedAt   time.Time    `json:"createdAt"`
+	ArchivedAt  *time.Time   `json:"archivedAt,omitempty"`
+	Strategy    AssignmentStrategy `json:"strategy,omitempty"`
+	EstimatedMinutes int     `json:"estimatedMinutes,omitempty"`
+	RequireReview bool       `json:"requireReview,omitempty"`
 }
 
 type AccountChoreResponse struct {
@@ -31,11 +47,14 @@ type AccountChoreResponse struct {
 	CompletedAt *time.Time       `json:"completedAt"`
 	Points      int              `json:"points"`
 	Chore       ChoreResponse    `json:"chore"`
+	RejectionReason string       `json:"rejectionReason,omitempty"`
+	BlockedBy   []ChoreInfo      `json:"blockedBy,omitempty"` // Prerequisite occurrences still to be done
 }
 
 type HouseholdResponse struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Timezone string    `json:"timezone"`
 }
 
 type LeaderboardEntryResponse struct {
@@ -45,13 +64,15 @@ type LeaderboardEntryResponse struct {
 }
 
 type HouseholdMemberResponse struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID   uuid.UUID     `json:"id"`
+	Name string        `json:"name"`
+	Role HouseholdRole `json:"role"`
 } 
 
 type CreateHouseholdResponse struct {
 	ID uuid.UUID `json:"id"`
 	Name string `json:"name"`
+	Timezone string `json:"timezone"`
 }
 
 type ChoreAssignmentResponse struct {
@@ -105,6 +126,10 @@ type NotificationResponse struct {
 	ReviewInfo   *ReviewInfo  `json:"reviewInfo,omitempty"`
 	Transaction  *TransactionInfo `json:"transactionInfo,omitempty"`
 	Split        *SplitInfo 	`json:"splitInfo,omitempty"`
+	Member       *ActorInfo   `json:"memberInfo,omitempty"`
+	Swap         *SwapInfo    `json:"swapInfo,omitempty"`
+	Household    *HouseholdInfo `json:"householdInfo,omitempty"`
+	RetractedAt  *time.Time   `json:"retractedAt,omitempty"`
 }
 
 type ActorInfo struct {
@@ -117,6 +142,7 @@ type ChoreInfo struct {
 	AccountChoreID uuid.UUID `json:"accountChoreId"`
 	Title          string    `json:"title"`
 	DueDate        time.Time `json:"dueDate"`
+	Photos         []ChorePhotoResponse `json:"photos,omitempty"`
 }
 
 type ReviewInfo struct {
@@ -124,6 +150,7 @@ type ReviewInfo struct {
 	Review   string    `json:"review"`
 	ChoreName string `json:"choreName"`
 	AccountChoreID uuid.UUID `json:"accountChoreId"`
+	Photos []ChorePhotoResponse `json:"photos,omitempty"`
 }
 
 type TransactionInfo struct {
@@ -142,11 +169,222 @@ type SplitInfo struct {
 	OwedToName string `json:"owedToName"`
 }
 
+type HouseholdInfo struct {
+	HouseholdID uuid.UUID `json:"householdId"`
+	Name        string    `json:"name"`
+}
+
+type SwapInfo struct {
+	SwapID        uuid.UUID  `json:"swapId"`
+	Status        SwapStatus `json:"status"`
+	RequesterID   uuid.UUID  `json:"requesterId"`
+	RecipientID   uuid.UUID  `json:"recipientId"`
+	OfferedTitle  string     `json:"offeredTitle"`
+	RequestedTitle string    `json:"requestedTitle,omitempty"`
+}
+
 type ChoreReviewResponse struct {
 	ID uuid.UUID `json:"id"`
+	AccountChoreID uuid.UUID `json:"accountChoreId"`
+	ChoreID uuid.UUID `json:"choreId"`
+	ChoreTitle string `json:"choreTitle"`
+	RevieweeID uuid.UUID `json:"revieweeId"`
+	RevieweeName string `json:"revieweeName"`
 	ReviewerID uuid.UUID `json:"reviewerId"`
 	ReviewerName string `json:"reviewerName"`
 	ReviewComment string `json:"reviewComment"`
-	ReviewerStatus string `json:"reviewerStatus"`
+	ReviewerStatus ReviewStatus `json:"reviewerStatus"`
+	Rating *int `json:"rating,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
+	MissedItems []ChecklistItemResponse `json:"missedItems,omitempty"`
+	Photos []ChorePhotoResponse `json:"photos,omitempty"`
+}
+
+// ChoreDependenciesResponse lists the chores a chore waits for and the chores
+// waiting for it.
+type ChoreDependenciesResponse struct {
+	ChoreID       uuid.UUID       `json:"choreId"`
+	Prerequisites []ChoreResponse `json:"prerequisites"`
+	Dependents    []ChoreResponse `json:"dependents"`
+}
+
+// ReviewListResponse is a set of reviews, newest first, with their aggregates.
+type ReviewListResponse struct {
+	Reviews []ChoreReviewResponse `json:"reviews"`
+	Summary ReviewSummary         `json:"summary"`
+}
+
+// ReviewSummary aggregates approvals and ratings. The rate and average are
+// omitted when no review counts towards them.
+type ReviewSummary struct {
+	Total         int      `json:"total"`
+	Approved      int      `json:"approved"`
+	Rejected      int      `json:"rejected"`
+	ApprovalRate  *float64 `json:"approvalRate,omitempty"`
+	Rated         int      `json:"rated"`
+	AverageRating *float64 `json:"averageRating,omitempty"`
+}
+
+// ChorePhotoResponse links to a photo and its thumbnail until ExpiresAt.
+type ChorePhotoResponse struct {
+	ID           uuid.UUID `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	ContentType  string    `json:"contentType"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	UploadedByID uuid.UUID `json:"uploadedById"`
+	CreatedAt    time.Time `json:"createdAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+type ChecklistItemResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Position    int        `json:"position"`
+	Checked     bool       `json:"checked"`
+	CheckedAt   *time.Time `json:"checkedAt,omitempty"`
+	CheckedByID *uuid.UUID `json:"checkedById,omitempty"`
+}
+
+type ChecklistResponse struct {
+	ChoreID    uuid.UUID               `json:"choreId"`
+	RequireAll bool                    `json:"requireAll"`
+	Items      []ChecklistItemResponse `json:"items"`
+}
+
+
+type HouseholdInviteResponse struct {
+	ID            uuid.UUID `json:"id"`
+	HouseholdID   uuid.UUID `json:"householdId"`
+	Code          string    `json:"code"`
+	Link          string    `json:"link"`
+	Email         *string   `json:"email"`
+	MaxUses       int       `json:"maxUses"`
+	UseCount      int       `json:"useCount"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	CreatedByID   uuid.UUID `json:"createdById"`
+	CreatedByName string    `json:"createdByName"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type ChoreSwapResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	HouseholdID uuid.UUID  `json:"householdId"`
+	Requester   ActorInfo  `json:"requester"`
+	Recipient   ActorInfo  `json:"recipient"`
+	Offered     ChoreInfo  `json:"offered"`
+	Requested   *ChoreInfo `json:"requested,omitempty"` // Empty for a one-way handoff
+	Message     string     `json:"message"`
+	Status      SwapStatus `json:"status"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	RespondedAt *time.Time `json:"respondedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type MemberAvailabilityResponse struct {
+	ID          uuid.UUID `json:"id"`
+	AccountID   uuid.UUID `json:"accountId"`
+	AccountName string    `json:"accountName"`
+	StartDate   string    `json:"startDate"`
+	EndDate     string    `json:"endDate"`
+	Note        string    `json:"note"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// CoverageEntryResponse is one member's side of the chores that changed hands
+// because someone was away.
+type CoverageEntryResponse struct {
+	AccountID             uuid.UUID `json:"accountId"`
+	AccountName           string    `json:"accountName"`
+	CoveredAssignments    int       `json:"coveredAssignments"`    // Others' turns this member took
+	CoveredPoints         int       `json:"coveredPoints"`
+	CoveredByOthers       int       `json:"coveredByOthers"`       // This member's turns others took
+	CoveredByOthersPoints int       `json:"coveredByOthersPoints"`
+}
+
+type CoverageReportResponse struct {
+	Month   time.Time               `json:"month"`
+	Members []CoverageEntryResponse `json:"members"`
+}
+
+type ChoreTemplateResponse struct {
+	ID               uuid.UUID      `json:"id"`
+	HouseholdID      *uuid.UUID     `json:"householdId"` // Empty for the starter pack
+	HouseholdName    string         `json:"householdName,omitempty"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	Category         string         `json:"category"`
+	Type             ChoreType      `json:"type"`
+	FrequencyType    *FrequencyType `json:"frequencyType"`
+	Schedule         []int          `json:"schedule"`
+	Recurrence       string         `json:"recurrence,omitempty"`
+	Points           int            `json:"points"`
+	EstimatedMinutes int            `json:"estimatedMinutes"`
+	Shared           bool           `json:"shared"`
+	BuiltIn          bool           `json:"builtIn"`
+	CreatedAt        *time.Time     `json:"createdAt,omitempty"`
+}
+
+type MemberDepartureResponse struct {
+	ReassignedAssignments int                        `json:"reassignedAssignments"`
+	SettledSplits         int                        `json:"settledSplits"`
+	OutstandingSplits     []TransactionSplitResponse `json:"outstandingSplits,omitempty"`
+}
+
+type AccountHouseholdExport struct {
+	HouseholdID   uuid.UUID     `json:"householdId"`
+	HouseholdName string        `json:"householdName"`
+	Role          HouseholdRole `json:"role"`
+	JoinedAt      time.Time     `json:"joinedAt"`
+}
+
+type AccountDataExport struct {
+	ExportedAt       time.Time                  `json:"exportedAt"`
+	Account          AccountResponse            `json:"account"`
+	Households       []AccountHouseholdExport   `json:"households"`
+	Assignments      []AccountChoreResponse     `json:"assignments"`
+	ReviewsWritten   []ChoreReviewResponse      `json:"reviewsWritten"`
+	TransactionsPaid []TransactionResponse      `json:"transactionsPaid"`
+	Splits           []TransactionSplitResponse `json:"splits"`
+}
+
+type JoinAttemptResponse struct {
+	ID          uuid.UUID `json:"id"`
+	AccountID   uuid.UUID `json:"accountId"`
+	AccountName string    `json:"accountName"`
+	Succeeded   bool      `json:"succeeded"`
+	IPAddress   string    `json:"ipAddress"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	HouseholdID uuid.UUID    `json:"householdId"`
+	Prefix      string       `json:"prefix"`
+	Scopes      []TokenScope `json:"scopes"`
+	ExpiresAt   *time.Time   `json:"expiresAt"`
+	LastUsedAt  *time.Time   `json:"lastUsedAt"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	Token       string       `json:"token,omitempty"` // Only returned once, on creation
+}
+
+type AuditLogEntryResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actorId"`
+	ActorName  string          `json:"actorName"`
+	Action     AuditAction     `json:"action"`
+	EntityType AuditEntityType `json:"entityType"`
+	EntityID   uuid.UUID       `json:"entityId"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type AuditLogPageResponse struct {
+	Entries []AuditLogEntryResponse `json:"entries"`
+	Total   int64                   `json:"total"`
+	Limit   int                     `json:"limit"`
+	Offset  int                     `json:"offset"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Session struct {
+	ID               uuid.UUID  `gorm:"primaryKey; type:uuid; default:gen_random_uuid()" json:"id"`
+	AccountID        uuid.UUID  `gorm:"not null; index" json:"accountId"`
+	RefreshTokenHash string     `gorm:"not null; uniqueIndex" json:"-"`
+	ExpiresAt        time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt        *time.Time `json:"revokedAt"`
+	CreatedAt        time.Time  `gorm:"not null; default:CURRENT_TIMESTAMP" json:"createdAt"`
+	UpdatedAt        time.Time  `gorm:"not null; default:CURRENT_TIMESTAMP" json:"updatedAt"`
+	Account          Account    `gorm:"foreignKey:AccountID"`
+}
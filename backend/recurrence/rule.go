@@ -0,0 +1,450 @@
+// Package recurrence expands chore schedules into concrete dates. Rules follow
+// the subset of RFC 5545 RRULE that makes sense for household chores: daily,
+// weekly and monthly frequencies with INTERVAL, BYDAY, BYMONTHDAY, COUNT,
+// UNTIL and WKST.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+var (
+	ErrInvalidRule     = errors.New("invalid recurrence rule")
+	ErrUnsupportedRule = errors.New("unsupported recurrence rule")
+)
+
+// maxPeriods bounds how far a rule is walked looking for occurrences, so rules
+// that can never match (e.g. BYMONTHDAY=30 in a February-only rule) terminate.
+const maxPeriods = 5000
+
+// WeekdayNum is a BYDAY entry. Ordinal selects the nth weekday of the month,
+// counting from the end when negative; zero means every such weekday.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+	WeekStart  time.Weekday
+
+	// floatingUntil keeps a date-only or floating UNTIL as written. It has no
+	// zone of its own and is read in the anchor's location.
+	floatingUntil string
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse reads an RRULE value, with or without the "RRULE:" prefix.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "RRULE:"), "rrule:")
+	if value == "" {
+		return nil, ErrInvalidRule
+	}
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	for _, part := range strings.Split(value, ";") {
+		key, val, found := strings.Cut(part, "=")
+		if !found || val == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = Frequency(strings.ToUpper(val))
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(val, time.UTC)
+			rule.Until = &until
+			if !strings.HasSuffix(val, "Z") {
+				rule.floatingUntil = val
+			}
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(val)
+		case "WKST":
+			weekday, ok := weekdayCodes[strings.ToUpper(val)]
+			if !ok {
+				err = ErrInvalidRule
+			}
+			rule.WeekStart = weekday
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedRule, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// parseUntil reads a UTC UNTIL value as is and a date-only or floating one
+// in loc.
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"20060102T150405", "20060102"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalidRule
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		if len(item) < 2 {
+			return nil, ErrInvalidRule
+		}
+		weekday, ok := weekdayCodes[item[len(item)-2:]]
+		if !ok {
+			return nil, ErrInvalidRule
+		}
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(prefix)
+			if err != nil {
+				return nil, ErrInvalidRule
+			}
+			day.Ordinal = ordinal
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseIntList(value string) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// Validate checks the combinations Parse and the chore API can produce.
+func (r *Rule) Validate() error {
+	switch r.Frequency {
+	case Daily, Weekly, Monthly:
+	case "":
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	default:
+		return fmt.Errorf("%w: FREQ=%s", ErrUnsupportedRule, r.Frequency)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("%w: INTERVAL must be positive", ErrInvalidRule)
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("%w: COUNT must be positive", ErrInvalidRule)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+	for _, day := range r.ByDay {
+		if day.Ordinal != 0 && r.Frequency != Monthly {
+			return fmt.Errorf("%w: numbered BYDAY is only supported for MONTHLY", ErrUnsupportedRule)
+		}
+		if day.Ordinal < -5 || day.Ordinal > 5 {
+			return fmt.Errorf("%w: BYDAY ordinal out of range", ErrInvalidRule)
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Frequency == Weekly {
+		return fmt.Errorf("%w: BYMONTHDAY cannot be used with WEEKLY", ErrInvalidRule)
+	}
+	for _, day := range r.ByMonthDay {
+		if day == 0 || day < -31 || day > 31 {
+			return fmt.Errorf("%w: BYMONTHDAY out of range", ErrInvalidRule)
+		}
+	}
+	return nil
+}
+
+// String formats the rule as an RRULE value without the "RRULE:" prefix.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayNames[day.Weekday]
+			if day.Ordinal != 0 {
+				days[i] = strconv.Itoa(day.Ordinal) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.floatingUntil != "" {
+		parts = append(parts, "UNTIL="+r.floatingUntil)
+	} else if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between returns the occurrences of a rule anchored at start whose dates fall
+// in [from, to). Dates are midnights in start's location.
+func (r *Rule) Between(start time.Time, from time.Time, to time.Time) []time.Time {
+	from = dateIn(from, start.Location())
+	to = dateIn(to, start.Location())
+
+	var dates []time.Time
+	r.each(start, to, func(date time.Time) bool {
+		if !date.Before(to) {
+			return false
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return true
+	})
+	return dates
+}
+
+// Next returns the first occurrence on or after from's date, or false when the
+// rule has ended.
+func (r *Rule) Next(start time.Time, from time.Time) (time.Time, bool) {
+	dates := r.NextN(start, from, 1)
+	if len(dates) == 0 {
+		return time.Time{}, false
+	}
+	return dates[0], true
+}
+
+// NextN returns up to n occurrences on or after from's date.
+func (r *Rule) NextN(start time.Time, from time.Time, n int) []time.Time {
+	from = dateIn(from, start.Location())
+
+	var dates []time.Time
+	if n <= 0 {
+		return dates
+	}
+	r.each(start, time.Time{}, func(date time.Time) bool {
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return len(dates) < n
+	})
+	return dates
+}
+
+// each walks the occurrences in order until yield returns false, the rule
+// ends, or (when limit is set) a period starts after limit.
+func (r *Rule) each(start time.Time, limit time.Time, yield func(time.Time) bool) {
+	start = dateIn(start, start.Location())
+	var until time.Time
+	if r.floatingUntil != "" {
+		if t, err := parseUntil(r.floatingUntil, start.Location()); err == nil {
+			until = dateIn(t, start.Location())
+		}
+	} else if r.Until != nil {
+		until = dateIn(*r.Until, start.Location())
+	}
+
+	count := 0
+	for period := 0; period < maxPeriods; period++ {
+		periodStart, dates := r.period(start, period)
+		if !limit.IsZero() && periodStart.After(limit) {
+			return
+		}
+
+		for _, date := range dates {
+			// The anchor itself counts as the first occurrence only when it matches
+			if date.Before(start) {
+				continue
+			}
+			if !until.IsZero() && date.After(until) {
+				return
+			}
+			count++
+			if !yield(date) {
+				return
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// period returns the first day of the nth period after start and the matching
+// dates within it, in order.
+func (r *Rule) period(start time.Time, n int) (time.Time, []time.Time) {
+	switch r.Frequency {
+	case Daily:
+		date := start.AddDate(0, 0, n*r.Interval)
+		if r.matchesWeekday(date) && r.matchesMonthDay(date) {
+			return date, []time.Time{date}
+		}
+		return date, nil
+
+	case Weekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := start.AddDate(0, 0, n*7*r.Interval-offset)
+		var dates []time.Time
+		for i := 0; i < 7; i++ {
+			date := weekStart.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && date.Weekday() == start.Weekday() || len(r.ByDay) > 0 && r.matchesWeekday(date) {
+				dates = append(dates, date)
+			}
+		}
+		return weekStart, dates
+
+	default:
+		monthStart := time.Date(start.Year(), start.Month()+time.Month(n*r.Interval), 1, 0, 0, 0, 0, start.Location())
+		return monthStart, r.monthDates(start, monthStart)
+	}
+}
+
+func (r *Rule) monthDates(start time.Time, monthStart time.Time) []time.Time {
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+
+	byMonthDay := map[int]bool{}
+	for _, day := range r.ByMonthDay {
+		if day < 0 {
+			day = daysInMonth + 1 + day
+		}
+		if day >= 1 && day <= daysInMonth {
+			byMonthDay[day] = true
+		}
+	}
+
+	byDay := map[int]bool{}
+	for _, weekday := range r.ByDay {
+		var matches []int
+		for day := 1; day <= daysInMonth; day++ {
+			if monthStart.AddDate(0, 0, day-1).Weekday() == weekday.Weekday {
+				matches = append(matches, day)
+			}
+		}
+		switch {
+		case weekday.Ordinal == 0:
+			for _, day := range matches {
+				byDay[day] = true
+			}
+		case weekday.Ordinal > 0 && weekday.Ordinal <= len(matches):
+			byDay[matches[weekday.Ordinal-1]] = true
+		case weekday.Ordinal < 0 && -weekday.Ordinal <= len(matches):
+			byDay[matches[len(matches)+weekday.Ordinal]] = true
+		}
+	}
+
+	var days []int
+	switch {
+	case len(r.ByMonthDay) > 0 && len(r.ByDay) > 0:
+		for day := range byMonthDay {
+			if byDay[day] {
+				days = append(days, day)
+			}
+		}
+	case len(r.ByMonthDay) > 0:
+		for day := range byMonthDay {
+			days = append(days, day)
+		}
+	case len(r.ByDay) > 0:
+		for day := range byDay {
+			days = append(days, day)
+		}
+	default:
+		// Like RFC 5545, months without the anchor's day are skipped
+		if start.Day() <= daysInMonth {
+			days = append(days, start.Day())
+		}
+	}
+	sort.Ints(days)
+
+	dates := make([]time.Time, len(days))
+	for i, day := range days {
+		dates[i] = monthStart.AddDate(0, 0, day-1)
+	}
+	return dates
+}
+
+func (r *Rule) matchesWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+	for _, day := range r.ByMonthDay {
+		if day == date.Day() || day < 0 && daysInMonth+1+day == date.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// dateIn truncates t to midnight of its calendar day in loc.
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
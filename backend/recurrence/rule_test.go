@@ -0,0 +1,109 @@
+package recurrence
+
+import (
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestBetween(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		to    time.Time
+		want  []string
+	}{
+		{
+			name:  "second tuesday",
+			rule:  "FREQ=MONTHLY;BYDAY=2TU",
+			start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2024-01-09", "2024-02-13", "2024-03-12"},
+		},
+		{
+			name:  "last friday",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2024-01-26", "2024-02-23", "2024-03-29"},
+		},
+		{
+			name:  "last day of month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"},
+		},
+		{
+			name:  "count",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3",
+			start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2024-01-01", "2024-01-03", "2024-01-08"},
+		},
+		{
+			name:  "date-only until across DST start",
+			rule:  "FREQ=DAILY;UNTIL=20240311",
+			start: time.Date(2024, 3, 8, 0, 0, 0, 0, newYork),
+			to:    time.Date(2024, 3, 20, 0, 0, 0, 0, newYork),
+			want:  []string{"2024-03-08", "2024-03-09", "2024-03-10", "2024-03-11"},
+		},
+		{
+			name:  "floating until across DST end",
+			rule:  "FREQ=DAILY;UNTIL=20241104T090000",
+			start: time.Date(2024, 11, 1, 0, 0, 0, 0, newYork),
+			to:    time.Date(2024, 11, 10, 0, 0, 0, 0, newYork),
+			want:  []string{"2024-11-01", "2024-11-02", "2024-11-03", "2024-11-04"},
+		},
+		{
+			name:  "utc until",
+			rule:  "FREQ=DAILY;UNTIL=20240311T030000Z",
+			start: time.Date(2024, 3, 8, 0, 0, 0, 0, newYork),
+			to:    time.Date(2024, 3, 20, 0, 0, 0, 0, newYork),
+			want:  []string{"2024-03-08", "2024-03-09", "2024-03-10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+
+			var got []string
+			for _, date := range rule.Between(tt.start, tt.start, tt.to) {
+				if date.Location() != tt.start.Location() {
+					t.Errorf("%s is in %s, want %s", date, date.Location(), tt.start.Location())
+				}
+				got = append(got, date.Format(time.DateOnly))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Between = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringKeepsUntilForm(t *testing.T) {
+	for _, value := range []string{
+		"FREQ=DAILY;UNTIL=20240311",
+		"FREQ=DAILY;UNTIL=20240311T090000",
+		"FREQ=DAILY;UNTIL=20240311T090000Z",
+	} {
+		rule, err := Parse(value)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", value, err)
+		}
+		if got := rule.String(); got != value {
+			t.Errorf("String() = %q, want %q", got, value)
+		}
+	}
+}
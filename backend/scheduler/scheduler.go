@@ -0,0 +1,79 @@
+// Package scheduler runs the periodic chore maintenance jobs in-process. Every
+// instance may run one; the jobs coordinate through database advisory locks.
+package scheduler
+
+import (
+	"chore-share/service"
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	DefaultInterval    = 5 * time.Minute
+	DefaultHorizonDays = service.DefaultHorizonDays
+)
+
+type Scheduler struct {
+	service     service.DBService
+	interval    time.Duration
+	horizonDays int
+	now         func() time.Time
+}
+
+func New(service service.DBService, interval time.Duration, horizonDays int) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if horizonDays <= 0 {
+		horizonDays = DefaultHorizonDays
+	}
+	return &Scheduler{
+		service:     service,
+		interval:    interval,
+		horizonDays: horizonDays,
+		now:         time.Now,
+	}
+}
+
+// Start runs the jobs immediately and then on every interval until ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.RunOnce()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce runs each job a single time. Failures are logged and retried on the
+// next tick.
+func (s *Scheduler) RunOnce() {
+	now := s.now()
+
+	// Top up first so chores whose assignment just went overdue have a next one
+	if created, err := s.service.ExtendRecurringAssignments(now, s.horizonDays); err != nil {
+		log.Printf("scheduler: extending recurring assignments: %v", err)
+	} else if created > 0 {
+		log.Printf("scheduler: planned %d assignments", created)
+	}
+
+	if overdue, err := s.service.MarkOverdueAssignments(now); err != nil {
+		log.Printf("scheduler: marking overdue assignments: %v", err)
+	} else if overdue > 0 {
+		log.Printf("scheduler: marked %d assignments overdue", overdue)
+	}
+
+	if expired, err := s.service.ExpireChoreSwaps(now); err != nil {
+		log.Printf("scheduler: expiring swap requests: %v", err)
+	} else if expired > 0 {
+		log.Printf("scheduler: expired %d swap requests", expired)
+	}
+}
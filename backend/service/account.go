@@ -0,0 +1,311 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const deletedAccountName = "Deleted user"
+
+var (
+	ErrInvalidName     = errors.New("name cannot be empty")
+	ErrInvalidTimezone = errors.New("unknown timezone")
+)
+
+func (s *dbService) UpdateAccountProfile(accountID uuid.UUID, update models.UpdateAccountRequestBody) (models.AccountResponse, error) {
+	updates := map[string]interface{}{}
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return models.AccountResponse{}, ErrInvalidName
+		}
+		updates["name"] = name
+	}
+	if update.AvatarURL != nil {
+		updates["avatar_url"] = strings.TrimSpace(*update.AvatarURL)
+	}
+	if update.Timezone != nil {
+		if !validTimezone(*update.Timezone) {
+			return models.AccountResponse{}, ErrInvalidTimezone
+		}
+		updates["timezone"] = *update.Timezone
+	}
+
+	if len(updates) > 0 {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			// Only the changed fields are logged: the audit log can't be scrubbed
+			// when the account is deleted, so it must not hold personal data
+			changed := make([]string, 0, len(updates))
+			for _, field := range []string{"name", "avatar_url", "timezone"} {
+				if _, ok := updates[field]; ok {
+					changed = append(changed, field)
+				}
+			}
+
+			updates["updated_at"] = time.Now()
+			if err := tx.Model(&models.Account{ID: accountID}).Updates(updates).Error; err != nil {
+				return err
+			}
+			return recordAccountAudit(tx, accountID, models.AuditActionMemberProfileUpdated,
+				map[string]interface{}{"changed": changed})
+		})
+		if err != nil {
+			return models.AccountResponse{}, err
+		}
+	}
+
+	return s.GetAccount(accountID)
+}
+
+// recordAccountAudit logs a change to the account itself in every household
+// it belongs to, since that is where other members see it.
+func recordAccountAudit(tx *gorm.DB, accountID uuid.UUID, action models.AuditAction, after interface{}) error {
+	var householdIDs []uuid.UUID
+	if err := tx.Model(&models.AccountHousehold{}).
+		Where("account_id = ?", accountID).
+		Pluck("household_id", &householdIDs).Error; err != nil {
+		return err
+	}
+	for _, householdID := range householdIDs {
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: householdID,
+			ActorID:     &accountID,
+			Action:      action,
+			EntityType:  models.AuditEntityHouseholdMember,
+			EntityID:    accountID,
+		}, nil, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteAccount removes the account from all of its households and scrubs its
+// personal data in a single transaction. Households it leaves empty are deleted.
+// The row itself is kept so notifications, reviews and splits that reference it
+// stay intact and simply show an anonymous user.
+func (s *dbService) DeleteAccount(accountID uuid.UUID, settleBalances bool) (models.MemberDepartureResponse, error) {
+	var response models.MemberDepartureResponse
+	var departures []memberDeparture
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var memberships []models.AccountHousehold
+		if err := tx.Where("account_id = ?", accountID).Find(&memberships).Error; err != nil {
+			return err
+		}
+
+		// Report every outstanding split at once rather than the first household's
+		if !settleBalances {
+			var splits []models.TransactionSplit
+			if err := tx.Preload("Transaction").Preload("OwedBy").Preload("OwedTo").
+				Where("is_settled = ? AND (owed_by_id = ? OR owed_to_id = ?)", false, accountID, accountID).
+				Find(&splits).Error; err != nil {
+				return err
+			}
+			if len(splits) > 0 {
+				for _, split := range splits {
+					response.OutstandingSplits = append(response.OutstandingSplits, splitResponse(split))
+				}
+				return ErrOutstandingBalances
+			}
+		}
+
+		for _, membership := range memberships {
+			if membership.Role == models.HouseholdRoleOwner {
+				successor, err := ownershipSuccessor(tx, membership.HouseholdID, accountID)
+				if err != nil {
+					return err
+				}
+				if successor != uuid.Nil {
+					if err := transferOwnership(tx, membership.HouseholdID, accountID, successor); err != nil {
+						return err
+					}
+				}
+			}
+
+			result, departure, err := s.removeMember(tx, membership.HouseholdID, accountID, accountID, true)
+			if err != nil {
+				return err
+			}
+			response.ReassignedAssignments += result.ReassignedAssignments
+			response.SettledSplits += result.SettledSplits
+			departures = append(departures, departure)
+
+			if len(departure.remainingMembers) == 0 {
+				if err := deleteHousehold(tx, membership.HouseholdID, accountID); err != nil {
+					return err
+				}
+			}
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.Session{}).
+			Where("account_id = ? AND revoked_at IS NULL", accountID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.PersonalAccessToken{}).
+			Where("account_id = ? AND revoked_at IS NULL", accountID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Account{ID: accountID}).Updates(map[string]interface{}{
+			"name":       deletedAccountName,
+			"google_id":  "deleted:" + accountID.String(),
+			"email":      "",
+			"avatar_url": "",
+			"timezone":   "",
+			"deleted_at": now,
+			"updated_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		// Logged in the households the account just left. There is no before
+		// snapshot since it would keep the personal data that was scrubbed.
+		for _, membership := range memberships {
+			if err := recordAudit(tx, models.AuditLog{
+				HouseholdID: membership.HouseholdID,
+				ActorID:     &accountID,
+				Action:      models.AuditActionAccountDeleted,
+				EntityType:  models.AuditEntityHouseholdMember,
+				EntityID:    accountID,
+			}, nil, map[string]interface{}{"name": deletedAccountName}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return response, err
+	}
+
+	for _, departure := range departures {
+		if err := s.announceDeparture(departure); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// ownershipSuccessor picks the longest-standing admin, falling back to the
+// longest-standing member. It returns uuid.Nil when nobody else is left.
+func ownershipSuccessor(tx *gorm.DB, householdID uuid.UUID, ownerID uuid.UUID) (uuid.UUID, error) {
+	var successor models.AccountHousehold
+	err := tx.Where("household_id = ? AND account_id <> ?", householdID, ownerID).
+		Order(gorm.Expr("CASE WHEN role = ? THEN 0 ELSE 1 END, created_at", models.HouseholdRoleAdmin)).
+		First(&successor).Error
+	if err == gorm.ErrRecordNotFound {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return successor.AccountID, nil
+}
+
+// ExportAccountData collects everything we store about the account.
+func (s *dbService) ExportAccountData(accountID uuid.UUID) (models.AccountDataExport, error) {
+	export := models.AccountDataExport{ExportedAt: time.Now()}
+
+	account, err := s.GetAccount(accountID)
+	if err != nil {
+		return export, err
+	}
+	export.Account = account
+
+	var memberships []models.AccountHousehold
+	if err := s.db.Preload("Household").Where("account_id = ?", accountID).Find(&memberships).Error; err != nil {
+		return export, err
+	}
+	export.Households = make([]models.AccountHouseholdExport, len(memberships))
+	for i, m := range memberships {
+		export.Households[i] = models.AccountHouseholdExport{
+			HouseholdID:   m.HouseholdID,
+			HouseholdName: m.Household.Name,
+			Role:          m.Role,
+			JoinedAt:      m.CreatedAt,
+		}
+	}
+
+	var assignments []models.AccountChore
+	if err := s.db.Preload("Chore").Where("account_id = ?", accountID).Order("due_date").Find(&assignments).Error; err != nil {
+		return export, err
+	}
+	export.Assignments = make([]models.AccountChoreResponse, len(assignments))
+	for i, ac := range assignments {
+		export.Assignments[i] = models.AccountChoreResponse{
+			ID:          ac.ID,
+			ChoreID:     ac.ChoreID,
+			AccountID:   ac.AccountID,
+			AccountName: account.Name,
+			DueDate:     ac.DueDate,
+			Status:      ac.Status,
+			CompletedAt: ac.CompletedAt,
+			Points:      ac.Points,
+			Chore: models.ChoreResponse{
+				ID:          ac.Chore.ID,
+				Title:       ac.Chore.Title,
+				Description: ac.Chore.Description,
+				Type:        ac.Chore.Type,
+				Recurrence:  ac.Chore.Recurrence,
+				HouseholdID: ac.Chore.HouseholdID,
+				CreatedAt:   ac.Chore.CreatedAt,
+			},
+		}
+	}
+
+	var reviews []models.ChoreReview
+	if err := s.db.Where("reviewer_id = ?", accountID).Order("created_at").Find(&reviews).Error; err != nil {
+		return export, err
+	}
+	export.ReviewsWritten = make([]models.ChoreReviewResponse, len(reviews))
+	for i, review := range reviews {
+		export.ReviewsWritten[i] = models.ChoreReviewResponse{
+			ID:             review.ID,
+			AccountChoreID: review.AccountChoreID,
+			ReviewerID:     review.ReviewerID,
+			ReviewerName:   account.Name,
+			ReviewComment:  review.Review,
+			ReviewerStatus: review.ReviewerStatus,
+			Rating:         review.Rating,
+			CreatedAt:      review.CreatedAt,
+		}
+	}
+
+	var transactions []models.Transaction
+	if err := s.db.Where("paid_by_id = ?", accountID).Order("spent_at").Find(&transactions).Error; err != nil {
+		return export, err
+	}
+	export.TransactionsPaid = make([]models.TransactionResponse, len(transactions))
+	for i, t := range transactions {
+		export.TransactionsPaid[i] = models.TransactionResponse{
+			ID:            t.ID,
+			Description:   t.Description,
+			AmountInCents: t.AmountInCents,
+			AccountID:     t.PaidByID,
+			HouseholdID:   t.HouseholdID,
+			SpentAt:       t.SpentAt,
+		}
+	}
+
+	var splits []models.TransactionSplit
+	if err := s.db.Preload("Transaction").Preload("OwedBy").Preload("OwedTo").
+		Where("owed_by_id = ? OR owed_to_id = ?", accountID, accountID).
+		Find(&splits).Error; err != nil {
+		return export, err
+	}
+	export.Splits = make([]models.TransactionSplitResponse, len(splits))
+	for i, split := range splits {
+		export.Splits[i] = splitResponse(split)
+	}
+
+	return export, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"chore-share/models"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+// AuditLogFilter narrows GetAuditLog. Zero values are ignored.
+type AuditLogFilter struct {
+	ActorID    *uuid.UUID
+	Action     models.AuditAction
+	EntityType models.AuditEntityType
+	EntityID   *uuid.UUID
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+	Offset     int
+}
+
+// recordAudit appends an entry to the household's audit log. It takes the
+// caller's transaction so the entry is only kept if the change itself commits.
+func recordAudit(tx *gorm.DB, entry models.AuditLog, before interface{}, after interface{}) error {
+	var err error
+	if entry.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+	return tx.Create(&entry).Error
+}
+
+func auditSnapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// Snapshots only carry the fields members care about, never secrets such as
+// password or token hashes.
+
+func choreSnapshot(chore models.Chore) map[string]interface{} {
+	return map[string]interface{}{
+		"title":            chore.Title,
+		"description":      chore.Description,
+		"type":             chore.Type,
+		"frequencyType":    chore.FrequencyType,
+		"endDate":          chore.EndDate,
+		"points":           chore.Points,
+		"archivedAt":       chore.ArchivedAt,
+		"strategy":         chore.Strategy,
+		"estimatedMinutes": chore.EstimatedMinutes,
+		"requireReview":    chore.RequireReview,
+	}
+}
+
+func assignmentSnapshot(assignment models.AccountChore) map[string]interface{} {
+	return map[string]interface{}{
+		"choreId":         assignment.ChoreID,
+		"accountId":       assignment.AccountID,
+		"dueDate":         assignment.DueDate,
+		"status":          assignment.Status,
+		"completedAt":     assignment.CompletedAt,
+		"points":          assignment.Points,
+		"coveredForId":    assignment.CoveredForID,
+		"rejectionReason": assignment.RejectionReason,
+	}
+}
+
+func splitSnapshot(split models.TransactionSplit) map[string]interface{} {
+	return map[string]interface{}{
+		"transactionId": split.TransactionID,
+		"owedById":      split.OwedByID,
+		"owedToId":      split.OwedToID,
+		"amountInCents": split.AmountInCents,
+		"isSettled":     split.IsSettled,
+		"settledAt":     split.SettledAt,
+	}
+}
+
+func memberSnapshot(membership models.AccountHousehold) map[string]interface{} {
+	return map[string]interface{}{
+		"accountId": membership.AccountID,
+		"role":      membership.Role,
+	}
+}
+
+func inviteSnapshot(invite models.HouseholdInvite) map[string]interface{} {
+	return map[string]interface{}{
+		"email":     invite.Email,
+		"maxUses":   invite.MaxUses,
+		"useCount":  invite.UseCount,
+		"expiresAt": invite.ExpiresAt,
+		"revokedAt": invite.RevokedAt,
+	}
+}
+
+func swapSnapshot(swap models.ChoreSwap) map[string]interface{} {
+	return map[string]interface{}{
+		"requesterId":           swap.RequesterID,
+		"recipientId":           swap.RecipientID,
+		"offeredAssignmentId":   swap.OfferedAssignmentID,
+		"requestedAssignmentId": swap.RequestedAssignmentID,
+		"status":                swap.Status,
+		"expiresAt":             swap.ExpiresAt,
+		"respondedAt":           swap.RespondedAt,
+	}
+}
+
+func availabilitySnapshot(availability models.MemberAvailability) map[string]interface{} {
+	return map[string]interface{}{
+		"accountId": availability.AccountID,
+		"startDate": availability.StartDate.Format(availabilityDateLayout),
+		"endDate":   availability.EndDate.Format(availabilityDateLayout),
+		"note":      availability.Note,
+	}
+}
+
+func checklistSnapshot(chore models.Chore, items []models.ChecklistItem) map[string]interface{} {
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title
+	}
+	return map[string]interface{}{
+		"checklist":        titles,
+		"requireChecklist": chore.RequireChecklist,
+	}
+}
+
+func checklistItemSnapshot(item models.ChecklistItem, state models.AssignmentChecklistItem) map[string]interface{} {
+	return map[string]interface{}{
+		"accountChoreId": state.AccountChoreID,
+		"title":          item.Title,
+		"checked":        state.Checked,
+		"checkedById":    state.CheckedByID,
+	}
+}
+
+func templateSnapshot(template models.ChoreTemplate) map[string]interface{} {
+	return map[string]interface{}{
+		"title":            template.Title,
+		"category":         template.Category,
+		"type":             template.Type,
+		"frequencyType":    template.FrequencyType,
+		"schedule":         template.Schedule,
+		"recurrence":       template.Recurrence,
+		"points":           template.Points,
+		"estimatedMinutes": template.EstimatedMinutes,
+		"shared":           template.Shared,
+	}
+}
+
+func accessTokenSnapshot(token models.PersonalAccessToken) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      token.Name,
+		"prefix":    token.Prefix,
+		"scopes":    token.ScopeList(),
+		"expiresAt": token.ExpiresAt,
+		"revokedAt": token.RevokedAt,
+	}
+}
+
+// GetAuditLog returns a page of the household's audit log, newest first.
+func (s *dbService) GetAuditLog(householdID uuid.UUID, filter AuditLogFilter) (models.AuditLogPageResponse, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	if filter.Limit > maxAuditPageSize {
+		filter.Limit = maxAuditPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	query := s.db.Model(&models.AuditLog{}).Where("household_id = ?", householdID)
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("created_at < ?", *filter.Until)
+	}
+
+	page := models.AuditLogPageResponse{Limit: filter.Limit, Offset: filter.Offset}
+	if err := query.Count(&page.Total).Error; err != nil {
+		return page, err
+	}
+
+	var entries []models.AuditLog
+	if err := query.Preload("Actor").
+		Order("created_at DESC, id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&entries).Error; err != nil {
+		return page, err
+	}
+
+	page.Entries = make([]models.AuditLogEntryResponse, len(entries))
+	for i, entry := range entries {
+		page.Entries[i] = models.AuditLogEntryResponse{
+			ID:         entry.ID,
+			ActorID:    entry.ActorID,
+			ActorName:  entry.Actor.Name,
+			Action:     entry.Action,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Before:     entry.Before,
+			After:      entry.After,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+	return page, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotHouseholdMember = errors.New("account is not a member of this household")
+	ErrAssigneeNotMember  = errors.New("assignee is not a member of this household")
+)
+
+// HouseholdEntity names a household-scoped record that can be addressed by ID.
+type HouseholdEntity string
+
+const (
+	HouseholdEntityChore         HouseholdEntity = "CHORE"
+	HouseholdEntityAccountChore  HouseholdEntity = "ACCOUNT_CHORE"
+	HouseholdEntityChoreSwap     HouseholdEntity = "CHORE_SWAP"
+	HouseholdEntityAvailability  HouseholdEntity = "MEMBER_AVAILABILITY"
+	HouseholdEntityChecklistItem HouseholdEntity = "CHECKLIST_ITEM"
+	HouseholdEntitySplit         HouseholdEntity = "TRANSACTION_SPLIT"
+	HouseholdEntityReview        HouseholdEntity = "CHORE_REVIEW"
+	HouseholdEntityNotification  HouseholdEntity = "NOTIFICATION"
+	HouseholdEntityInvite        HouseholdEntity = "HOUSEHOLD_INVITE"
+	HouseholdEntityMember        HouseholdEntity = "HOUSEHOLD_MEMBER"
+	HouseholdEntityTemplate      HouseholdEntity = "CHORE_TEMPLATE"
+)
+
+func (s *dbService) GetHouseholdMembership(accountID uuid.UUID, householdID uuid.UUID) (models.AccountHousehold, error) {
+	var membership models.AccountHousehold
+	err := s.db.Joins("JOIN households ON households.id = account_households.household_id AND households.deleted_at IS NULL").
+		Where("account_households.account_id = ? AND account_households.household_id = ?", accountID, householdID).
+		First(&membership).Error
+	if err == gorm.ErrRecordNotFound {
+		return models.AccountHousehold{}, ErrNotHouseholdMember
+	}
+	if err != nil {
+		return models.AccountHousehold{}, err
+	}
+	return membership, nil
+}
+
+// EntityInHousehold reports whether the given record exists and belongs to the household.
+func (s *dbService) EntityInHousehold(entity HouseholdEntity, entityID uuid.UUID, householdID uuid.UUID) (bool, error) {
+	var query *gorm.DB
+	switch entity {
+	case HouseholdEntityChore:
+		query = s.db.Model(&models.Chore{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntityChoreSwap:
+		query = s.db.Model(&models.ChoreSwap{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntityAvailability:
+		query = s.db.Model(&models.MemberAvailability{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntityChecklistItem:
+		query = s.db.Model(&models.ChecklistItem{}).
+			Joins("JOIN chores ON chores.id = checklist_items.chore_id").
+			Where("checklist_items.id = ? AND chores.household_id = ?", entityID, householdID)
+	case HouseholdEntityAccountChore:
+		query = s.db.Model(&models.AccountChore{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntitySplit:
+		query = s.db.Model(&models.TransactionSplit{}).
+			Joins("JOIN transactions ON transactions.id = transaction_splits.transaction_id").
+			Where("transaction_splits.id = ? AND transactions.household_id = ?", entityID, householdID)
+	case HouseholdEntityReview:
+		query = s.db.Model(&models.ChoreReview{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntityNotification:
+		query = s.db.Model(&models.Notification{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntityInvite:
+		query = s.db.Model(&models.HouseholdInvite{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntityMember:
+		query = s.db.Model(&models.AccountHousehold{}).
+			Where("account_id = ? AND household_id = ?", entityID, householdID)
+	case HouseholdEntityTemplate:
+		// Starter templates are shared by every household
+		if _, ok := starterTemplateByID(entityID); ok {
+			return true, nil
+		}
+		query = s.db.Model(&models.ChoreTemplate{}).
+			Where("id = ? AND household_id = ?", entityID, householdID)
+	default:
+		return false, errors.New("unknown household entity")
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ensureHouseholdMembers fails with ErrAssigneeNotMember unless every account belongs to the household.
+func ensureHouseholdMembers(tx *gorm.DB, householdID uuid.UUID, accountIDs []uuid.UUID) error {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&models.AccountHousehold{}).
+		Where("household_id = ? AND account_id IN ?", householdID, accountIDs).
+		Distinct("account_id").
+		Count(&count).Error; err != nil {
+		return err
+	}
+
+	unique := map[uuid.UUID]struct{}{}
+	for _, id := range accountIDs {
+		unique[id] = struct{}{}
+	}
+	if int(count) != len(unique) {
+		return ErrAssigneeNotMember
+	}
+	return nil
+}
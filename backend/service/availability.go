@@ -0,0 +1,361 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidAvailability  = errors.New("availability must end on or after its start date")
+	ErrAvailabilityNotFound = errors.New("availability not found")
+)
+
+const availabilityDateLayout = "2006-01-02"
+
+// availabilityCalendar holds who is away on which days, keyed by account.
+type availabilityCalendar map[uuid.UUID][]models.MemberAvailability
+
+// loadAvailability reads the household's availability ranges that haven't
+// ended before from.
+func loadAvailability(tx *gorm.DB, householdID uuid.UUID, from time.Time) (availabilityCalendar, error) {
+	var ranges []models.MemberAvailability
+	if err := tx.Where("household_id = ? AND end_date >= ?", householdID, from.Format(availabilityDateLayout)).
+		Find(&ranges).Error; err != nil {
+		return nil, err
+	}
+
+	calendar := availabilityCalendar{}
+	for _, r := range ranges {
+		calendar[r.AccountID] = append(calendar[r.AccountID], r)
+	}
+	return calendar, nil
+}
+
+// away reports whether the member is unavailable on day, a date in the
+// household's timezone.
+func (c availabilityCalendar) away(accountID uuid.UUID, day time.Time) bool {
+	date := day.Format(availabilityDateLayout)
+	for _, r := range c[accountID] {
+		if r.StartDate.Format(availabilityDateLayout) <= date && date <= r.EndDate.Format(availabilityDateLayout) {
+			return true
+		}
+	}
+	return false
+}
+
+// assignee picks who does the occurrence on day for the given rotation slot:
+// the slot's member, or the next available member in rotation order when they
+// are away. coveredFor is set to the slot's member when someone else steps in.
+// When everyone is away the slot's member keeps it.
+func (c availabilityCalendar) assignee(rotations []models.ChoreRotation, order int, day time.Time) (accountID uuid.UUID, coveredFor *uuid.UUID) {
+	owner := rotations[order].AccountID
+	if !c.away(owner, day) {
+		return owner, nil
+	}
+	for i := 1; i < len(rotations); i++ {
+		candidate := rotations[(order+i)%len(rotations)].AccountID
+		if !c.away(candidate, day) {
+			return candidate, &owner
+		}
+	}
+	return owner, nil
+}
+
+// cover hands an assignment whose holder is away on day to the next available
+// member of the chore's rotation. It reports whether the assignee changed.
+func (c availabilityCalendar) cover(rotations []models.ChoreRotation, assignment *models.AccountChore, day time.Time) bool {
+	if !c.away(assignment.AccountID, day) {
+		return false
+	}
+
+	order := -1
+	for i, rotation := range rotations {
+		if rotation.AccountID == assignment.AccountID {
+			order = i
+			break
+		}
+	}
+
+	holder := assignment.AccountID
+	for i := 1; i <= len(rotations); i++ {
+		candidate := rotations[(order+i+len(rotations))%len(rotations)].AccountID
+		if candidate != holder && !c.away(candidate, day) {
+			assignment.AccountID = candidate
+			if assignment.CoveredForID == nil {
+				assignment.CoveredForID = &holder
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// CreateMemberAvailability records that a member is away and hands their
+// PENDING and PLANNED assignments in the range to whoever is available.
+func (s *dbService) CreateMemberAvailability(availability *models.MemberAvailability) (models.MemberAvailabilityResponse, error) {
+	if availability.EndDate.Before(availability.StartDate) {
+		return models.MemberAvailabilityResponse{}, ErrInvalidAvailability
+	}
+
+	var reassignedPending []models.AccountChore
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(availability).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: availability.HouseholdID,
+			ActorID:     &availability.AccountID,
+			Action:      models.AuditActionAvailabilityCreated,
+			EntityType:  models.AuditEntityMemberAvailability,
+			EntityID:    availability.ID,
+		}, nil, availabilitySnapshot(*availability)); err != nil {
+			return err
+		}
+
+		reassigned, err := coverAwayMember(tx, *availability)
+		if err != nil {
+			return err
+		}
+		for _, assignment := range reassigned {
+			if assignment.Status == models.AssignmentStatusPending {
+				reassignedPending = append(reassignedPending, assignment)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.MemberAvailabilityResponse{}, err
+	}
+
+	for _, assignment := range reassignedPending {
+		if err := s.notifyHousehold(models.NotificationActionChoreAssigned, assignment); err != nil {
+			return models.MemberAvailabilityResponse{}, err
+		}
+	}
+
+	var account models.Account
+	if err := s.db.Unscoped().Select("name").First(&account, availability.AccountID).Error; err != nil {
+		return models.MemberAvailabilityResponse{}, err
+	}
+	availability.Account = account
+	return availabilityResponse(*availability), nil
+}
+
+// coverAwayMember reassigns the member's open assignments that fall within the
+// availability range. Rotating chores go to the next available member in their
+// rotation, everything else to the least loaded available member.
+func coverAwayMember(tx *gorm.DB, availability models.MemberAvailability) ([]models.AccountChore, error) {
+	loc, err := householdLocation(tx, availability.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Date(availability.StartDate.Year(), availability.StartDate.Month(), availability.StartDate.Day(), 0, 0, 0, 0, loc)
+	until := time.Date(availability.EndDate.Year(), availability.EndDate.Month(), availability.EndDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+
+	var assignments []models.AccountChore
+	if err := tx.Where("household_id = ? AND account_id = ? AND status IN ? AND due_date >= ? AND due_date < ?",
+		availability.HouseholdID, availability.AccountID, swappableStatuses, from, until).
+		Order("due_date").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	calendar, err := loadAvailability(tx, availability.HouseholdID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []uuid.UUID
+	if err := tx.Model(&models.AccountHousehold{}).
+		Where("household_id = ?", availability.HouseholdID).
+		Pluck("account_id", &members).Error; err != nil {
+		return nil, err
+	}
+
+	var reassigned []models.AccountChore
+	for _, assignment := range assignments {
+		before := assignmentSnapshot(assignment)
+		day := assignment.DueDate.In(loc)
+
+		var rotations []models.ChoreRotation
+		if err := tx.Where("chore_id = ?", assignment.ChoreID).Order("rotation_order").Find(&rotations).Error; err != nil {
+			return nil, err
+		}
+
+		changed := false
+		if len(rotations) > 1 {
+			changed = calendar.cover(rotations, &assignment, day)
+		} else {
+			var available []uuid.UUID
+			for _, member := range members {
+				if member != assignment.AccountID && !calendar.away(member, day) {
+					available = append(available, member)
+				}
+			}
+			if len(available) > 0 {
+				assignee, err := leastLoadedMember(tx, availability.HouseholdID, available)
+				if err != nil {
+					return nil, err
+				}
+				holder := assignment.AccountID
+				assignment.AccountID = assignee
+				if assignment.CoveredForID == nil {
+					assignment.CoveredForID = &holder
+				}
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		if err := tx.Model(&assignment).Updates(map[string]interface{}{
+			"account_id":     assignment.AccountID,
+			"covered_for_id": assignment.CoveredForID,
+		}).Error; err != nil {
+			return nil, err
+		}
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: availability.HouseholdID,
+			ActorID:     &availability.AccountID,
+			Action:      models.AuditActionAssignmentReassigned,
+			EntityType:  models.AuditEntityAccountChore,
+			EntityID:    assignment.ID,
+		}, before, assignmentSnapshot(assignment)); err != nil {
+			return nil, err
+		}
+		reassigned = append(reassigned, assignment)
+	}
+	return reassigned, nil
+}
+
+// GetMemberAvailability lists the household's current and upcoming absences.
+func (s *dbService) GetMemberAvailability(householdID uuid.UUID) ([]models.MemberAvailabilityResponse, error) {
+	loc, err := householdLocation(s.db, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranges []models.MemberAvailability
+	if err := s.db.Preload("Account").
+		Where("household_id = ? AND end_date >= ?", householdID, time.Now().In(loc).Format(availabilityDateLayout)).
+		Order("start_date").
+		Find(&ranges).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]models.MemberAvailabilityResponse, len(ranges))
+	for i, r := range ranges {
+		response[i] = availabilityResponse(r)
+	}
+	return response, nil
+}
+
+// DeleteMemberAvailability removes one of the member's absences. Assignments
+// already handed to others stay with them.
+func (s *dbService) DeleteMemberAvailability(availabilityID uuid.UUID, accountID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var availability models.MemberAvailability
+		if err := tx.Where("id = ? AND account_id = ?", availabilityID, accountID).
+			First(&availability).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrAvailabilityNotFound
+			}
+			return err
+		}
+
+		if err := tx.Delete(&availability).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: availability.HouseholdID,
+			ActorID:     &accountID,
+			Action:      models.AuditActionAvailabilityDeleted,
+			EntityType:  models.AuditEntityMemberAvailability,
+			EntityID:    availability.ID,
+		}, availabilitySnapshot(availability), nil)
+	})
+}
+
+// GetCoverageReport sums, per member, the assignments due in the month that
+// were taken over from someone who was away, and the ones others took over
+// from them. A zero month means the household's current month.
+func (s *dbService) GetCoverageReport(householdID uuid.UUID, month time.Time) (models.CoverageReportResponse, error) {
+	report := models.CoverageReportResponse{Members: []models.CoverageEntryResponse{}}
+
+	loc, err := householdLocation(s.db, householdID)
+	if err != nil {
+		return report, err
+	}
+	if month.IsZero() {
+		month = time.Now()
+	} else {
+		month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	}
+	start, end := monthBounds(month, loc)
+	report.Month = start
+
+	var members []models.HouseholdMemberResponse
+	if err := s.db.Table("account_households").
+		Select("accounts.id, accounts.name").
+		Joins("JOIN accounts ON accounts.id = account_households.account_id").
+		Where("account_households.household_id = ?", householdID).
+		Order("accounts.name").
+		Scan(&members).Error; err != nil {
+		return report, err
+	}
+
+	var rows []struct {
+		AccountID    uuid.UUID
+		CoveredForID uuid.UUID
+		Total        int
+		Points       int
+	}
+	if err := s.db.Model(&models.AccountChore{}).
+		Select("account_id, covered_for_id, COUNT(*) as total, COALESCE(SUM(points), 0) as points").
+		Where("household_id = ? AND covered_for_id IS NOT NULL AND status <> ?", householdID, models.AssignmentStatusCancelled).
+		Where("due_date >= ? AND due_date < ?", start, end).
+		Group("account_id, covered_for_id").
+		Scan(&rows).Error; err != nil {
+		return report, err
+	}
+
+	entries := map[uuid.UUID]*models.CoverageEntryResponse{}
+	for _, member := range members {
+		report.Members = append(report.Members, models.CoverageEntryResponse{AccountID: member.ID, AccountName: member.Name})
+	}
+	for i := range report.Members {
+		entries[report.Members[i].AccountID] = &report.Members[i]
+	}
+	for _, row := range rows {
+		if entry, ok := entries[row.AccountID]; ok {
+			entry.CoveredAssignments += row.Total
+			entry.CoveredPoints += row.Points
+		}
+		if entry, ok := entries[row.CoveredForID]; ok {
+			entry.CoveredByOthers += row.Total
+			entry.CoveredByOthersPoints += row.Points
+		}
+	}
+	return report, nil
+}
+
+func availabilityResponse(availability models.MemberAvailability) models.MemberAvailabilityResponse {
+	return models.MemberAvailabilityResponse{
+		ID:          availability.ID,
+		AccountID:   availability.AccountID,
+		AccountName: availability.Account.Name,
+		StartDate:   availability.StartDate.Format(availabilityDateLayout),
+		EndDate:     availability.EndDate.Format(availabilityDateLayout),
+		Note:        availability.Note,
+		CreatedAt:   availability.CreatedAt,
+	}
+}
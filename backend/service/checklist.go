@@ -0,0 +1,281 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrChecklistIncomplete   = errors.New("every checklist item must be checked before the chore can be completed")
+	ErrAssignmentClosed      = errors.New("assignment is no longer open")
+)
+
+// ChecklistItemInput is one entry of a replacement checklist. A nil ID adds a
+// new item.
+type ChecklistItemInput struct {
+	ID    *uuid.UUID
+	Title string
+}
+
+// newChecklist turns titles into the items of a chore being created.
+func newChecklist(titles []string) ([]models.ChecklistItem, error) {
+	items := make([]models.ChecklistItem, len(titles))
+	for i, title := range titles {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			return nil, ErrInvalidName
+		}
+		items[i] = models.ChecklistItem{Title: title, Position: i}
+	}
+	return items, nil
+}
+
+// UpdateChecklist replaces the chore's checklist. Items left out are marked
+// removed rather than deleted so past check state and reviews keep them.
+func (s *dbService) UpdateChecklist(choreID uuid.UUID, items []ChecklistItemInput, requireAll *bool, actorID uuid.UUID) (models.ChecklistResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var chore models.Chore
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&chore, choreID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrChoreNotFound
+			}
+			return err
+		}
+		if chore.ArchivedAt != nil {
+			return ErrChoreArchived
+		}
+
+		current, err := activeChecklist(tx, choreID)
+		if err != nil {
+			return err
+		}
+		before := checklistSnapshot(chore, current)
+
+		existing := map[uuid.UUID]models.ChecklistItem{}
+		for _, item := range current {
+			existing[item.ID] = item
+		}
+
+		kept := map[uuid.UUID]bool{}
+		for position, input := range items {
+			title := strings.TrimSpace(input.Title)
+			if title == "" {
+				return ErrInvalidName
+			}
+
+			if input.ID == nil {
+				if err := tx.Create(&models.ChecklistItem{ChoreID: choreID, Title: title, Position: position}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			item, ok := existing[*input.ID]
+			if !ok {
+				return ErrChecklistItemNotFound
+			}
+			kept[item.ID] = true
+			if err := tx.Model(&item).Updates(map[string]interface{}{"title": title, "position": position}).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		for _, item := range current {
+			if !kept[item.ID] {
+				if err := tx.Model(&item).Update("removed_at", now).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if requireAll != nil && *requireAll != chore.RequireChecklist {
+			chore.RequireChecklist = *requireAll
+			if err := tx.Model(&chore).Update("require_checklist", chore.RequireChecklist).Error; err != nil {
+				return err
+			}
+		}
+
+		updated, err := activeChecklist(tx, choreID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: chore.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionChoreUpdated,
+			EntityType:  models.AuditEntityChore,
+			EntityID:    chore.ID,
+		}, before, checklistSnapshot(chore, updated))
+	})
+	if err != nil {
+		return models.ChecklistResponse{}, err
+	}
+
+	return s.GetChoreChecklist(choreID)
+}
+
+// GetChoreChecklist returns the chore's current checklist, without check state.
+func (s *dbService) GetChoreChecklist(choreID uuid.UUID) (models.ChecklistResponse, error) {
+	var chore models.Chore
+	if err := s.db.First(&chore, choreID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return models.ChecklistResponse{}, ErrChoreNotFound
+		}
+		return models.ChecklistResponse{}, err
+	}
+
+	items, err := activeChecklist(s.db, choreID)
+	if err != nil {
+		return models.ChecklistResponse{}, err
+	}
+	return checklistResponse(chore, items, nil), nil
+}
+
+// GetAssignmentChecklist returns the assignment's checklist with what has been
+// checked. Completed assignments show the items as they were at completion.
+func (s *dbService) GetAssignmentChecklist(accountChoreID uuid.UUID) (models.ChecklistResponse, error) {
+	var assignment models.AccountChore
+	if err := s.db.Preload("Chore").First(&assignment, accountChoreID).Error; err != nil {
+		return models.ChecklistResponse{}, err
+	}
+	return assignmentChecklist(s.db, assignment)
+}
+
+func assignmentChecklist(tx *gorm.DB, assignment models.AccountChore) (models.ChecklistResponse, error) {
+	var items []models.ChecklistItem
+	query := tx.Where("chore_id = ?", assignment.ChoreID)
+	if assignment.CompletedAt != nil {
+		query = query.Where("created_at <= ? AND (removed_at IS NULL OR removed_at > ?)", assignment.CompletedAt, assignment.CompletedAt)
+	} else {
+		query = query.Where("removed_at IS NULL")
+	}
+	if err := query.Order("position").Find(&items).Error; err != nil {
+		return models.ChecklistResponse{}, err
+	}
+
+	var states []models.AssignmentChecklistItem
+	if err := tx.Where("account_chore_id = ?", assignment.ID).Find(&states).Error; err != nil {
+		return models.ChecklistResponse{}, err
+	}
+	checked := map[uuid.UUID]models.AssignmentChecklistItem{}
+	for _, state := range states {
+		checked[state.ChecklistItemID] = state
+	}
+
+	return checklistResponse(assignment.Chore, items, checked), nil
+}
+
+// CheckChecklistItem checks or unchecks an item on an open assignment.
+func (s *dbService) CheckChecklistItem(accountChoreID uuid.UUID, itemID uuid.UUID, checked bool, actorID uuid.UUID) (models.ChecklistResponse, error) {
+	var response models.ChecklistResponse
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var assignment models.AccountChore
+		if err := tx.Preload("Chore").First(&assignment, accountChoreID).Error; err != nil {
+			return err
+		}
+		open := false
+		for _, status := range openAssignmentStatuses {
+			open = open || assignment.Status == status
+		}
+		if !open {
+			return ErrAssignmentClosed
+		}
+
+		var item models.ChecklistItem
+		if err := tx.Where("id = ? AND chore_id = ? AND removed_at IS NULL", itemID, assignment.ChoreID).
+			First(&item).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrChecklistItemNotFound
+			}
+			return err
+		}
+
+		// Items nobody has touched yet have no row and count as unchecked
+		previous := models.AssignmentChecklistItem{AccountChoreID: assignment.ID, ChecklistItemID: item.ID}
+		if err := tx.Where("account_chore_id = ? AND checklist_item_id = ?", assignment.ID, item.ID).
+			First(&previous).Error; err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		state := models.AssignmentChecklistItem{
+			AccountChoreID:  assignment.ID,
+			ChecklistItemID: item.ID,
+			Checked:         checked,
+		}
+		if checked {
+			now := time.Now()
+			state.CheckedAt = &now
+			state.CheckedByID = &actorID
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "account_chore_id"}, {Name: "checklist_item_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"checked", "checked_at", "checked_by_id"}),
+		}).Create(&state).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: assignment.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionChecklistItemChecked,
+			EntityType:  models.AuditEntityChecklistItem,
+			EntityID:    item.ID,
+		}, checklistItemSnapshot(item, previous), checklistItemSnapshot(item, state)); err != nil {
+			return err
+		}
+
+		var err error
+		response, err = assignmentChecklist(tx, assignment)
+		return err
+	})
+	return response, err
+}
+
+// checklistComplete reports whether every current item is checked for the
+// assignment.
+func checklistComplete(tx *gorm.DB, assignment models.AccountChore) (bool, error) {
+	var unchecked int64
+	err := tx.Model(&models.ChecklistItem{}).
+		Where("chore_id = ? AND removed_at IS NULL", assignment.ChoreID).
+		Where("NOT EXISTS (SELECT 1 FROM assignment_checklist_items WHERE assignment_checklist_items.checklist_item_id = checklist_items.id AND assignment_checklist_items.account_chore_id = ? AND assignment_checklist_items.checked)", assignment.ID).
+		Count(&unchecked).Error
+	return unchecked == 0, err
+}
+
+func activeChecklist(tx *gorm.DB, choreID uuid.UUID) ([]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	err := tx.Where("chore_id = ? AND removed_at IS NULL", choreID).Order("position").Find(&items).Error
+	return items, err
+}
+
+func checklistResponse(chore models.Chore, items []models.ChecklistItem, checked map[uuid.UUID]models.AssignmentChecklistItem) models.ChecklistResponse {
+	response := models.ChecklistResponse{
+		ChoreID:    chore.ID,
+		RequireAll: chore.RequireChecklist,
+		Items:      make([]models.ChecklistItemResponse, len(items)),
+	}
+	for i, item := range items {
+		response.Items[i] = checklistItemResponse(item)
+		if state, ok := checked[item.ID]; ok && state.Checked {
+			response.Items[i].Checked = true
+			response.Items[i].CheckedAt = state.CheckedAt
+			response.Items[i].CheckedByID = state.CheckedByID
+		}
+	}
+	return response
+}
+
+func checklistItemResponse(item models.ChecklistItem) models.ChecklistItemResponse {
+	return models.ChecklistItemResponse{
+		ID:       item.ID,
+		Title:    item.Title,
+		Position: item.Position,
+	}
+}
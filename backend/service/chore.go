@@ -0,0 +1,568 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrChoreNotFound      = errors.New("chore not found")
+	ErrInvalidChorePoints = errors.New("points cannot be negative")
+	ErrNoAssignees        = errors.New("a chore needs at least one assignee")
+	ErrChoreArchived      = errors.New("chore is archived")
+	ErrInvalidEstimate    = errors.New("estimated minutes cannot be negative")
+)
+
+// ChoreUpdate holds the changes to apply to a chore. Nil fields are left as
+// they are.
+type ChoreUpdate struct {
+	Title            *string
+	Description      *string
+	Points           *int
+	EndDate          *time.Time
+	Frequency        *models.FrequencyType // Legacy frequency, used with Schedule
+	Recurrence       *string               // RRULE, replaces the schedule of a recurring chore
+	RecurrenceStart  *time.Time
+	Schedule         []int       // Legacy days of the week, 1-7 for Monday-Sunday
+	Assignees        []uuid.UUID // The new rotation, in order
+	EstimatedMinutes *int
+	Strategy         *models.AssignmentStrategy
+	RequireReview    *bool
+}
+
+// UpdateChore edits a chore and regenerates its PENDING and PLANNED assignments
+// when the schedule, rotation, due date or points change. Completed and overdue
+// assignments are history and left alone. Everyone whose open assignments were
+// affected is notified, as are both sides of any swap the change overtook.
+func (s *dbService) UpdateChore(choreID uuid.UUID, update ChoreUpdate, actorID uuid.UUID) (models.ChoreResponse, error) {
+	var chore models.Chore
+	var affected map[uuid.UUID]bool
+	var cancelledSwaps []models.ChoreSwap
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&chore, choreID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrChoreNotFound
+			}
+			return err
+		}
+		if chore.ArchivedAt != nil {
+			return ErrChoreArchived
+		}
+		before := choreSnapshot(chore)
+
+		if update.Title != nil {
+			title := strings.TrimSpace(*update.Title)
+			if title == "" {
+				return ErrInvalidName
+			}
+			chore.Title = title
+		}
+		if update.Description != nil {
+			chore.Description = *update.Description
+		}
+		if update.Points != nil {
+			if *update.Points < 0 {
+				return ErrInvalidChorePoints
+			}
+			chore.Points = *update.Points
+		}
+		if update.EndDate != nil {
+			chore.EndDate = *update.EndDate
+		}
+		if update.EstimatedMinutes != nil {
+			if *update.EstimatedMinutes < 0 {
+				return ErrInvalidEstimate
+			}
+			chore.EstimatedMinutes = *update.EstimatedMinutes
+		}
+		if update.RequireReview != nil {
+			chore.RequireReview = *update.RequireReview
+		}
+		strategyChanged := false
+		if update.Strategy != nil && *update.Strategy != chore.Strategy {
+			if !validStrategy(*update.Strategy) {
+				return ErrInvalidStrategy
+			}
+			chore.Strategy = *update.Strategy
+			strategyChanged = true
+		}
+
+		scheduleChanged := false
+		if chore.Type == models.ChoreTypeRecurring && (update.Recurrence != nil || update.Frequency != nil || update.Schedule != nil || update.RecurrenceStart != nil) {
+			if update.Schedule != nil {
+				if err := replaceChoreSchedule(tx, &chore, update.Schedule); err != nil {
+					return err
+				}
+			}
+
+			var schedule []models.ChoreSchedule
+			if err := tx.Where("chore_id = ?", chore.ID).Find(&schedule).Error; err != nil {
+				return err
+			}
+			switch {
+			case update.Recurrence != nil:
+				chore.Recurrence = *update.Recurrence
+			case update.Frequency != nil || update.Schedule != nil:
+				if update.Frequency != nil {
+					chore.FrequencyType = update.Frequency
+				}
+				chore.Recurrence = ""
+			}
+			if update.RecurrenceStart != nil {
+				chore.RecurrenceStart = update.RecurrenceStart
+			}
+			if err := prepareRecurrence(&chore, schedule); err != nil {
+				return err
+			}
+			scheduleChanged = true
+		}
+
+		if update.Assignees != nil {
+			if len(update.Assignees) == 0 {
+				return ErrNoAssignees
+			}
+			if err := ensureHouseholdMembers(tx, chore.HouseholdID, update.Assignees); err != nil {
+				return err
+			}
+			if chore.Type == models.ChoreTypeRecurring {
+				if err := replaceChoreRotation(tx, &chore, update.Assignees); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Save(&chore).Error; err != nil {
+			return err
+		}
+
+		var err error
+		if chore.Type == models.ChoreTypeRecurring {
+			affected, cancelledSwaps, err = s.regenerateRecurringAssignments(tx, &chore, scheduleChanged || strategyChanged || update.Assignees != nil, actorID)
+		} else {
+			affected, err = regenerateOneTimeAssignment(tx, &chore, update.Assignees)
+		}
+		if err != nil {
+			return err
+		}
+
+		after := choreSnapshot(chore)
+		if update.Assignees != nil {
+			after["assigneeIds"] = update.Assignees
+		}
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: chore.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionChoreUpdated,
+			EntityType:  models.AuditEntityChore,
+			EntityID:    chore.ID,
+		}, before, after)
+	})
+	if err != nil {
+		return models.ChoreResponse{}, err
+	}
+
+	if len(affected) > 0 {
+		recipients := make([]uuid.UUID, 0, len(affected))
+		for accountID := range affected {
+			recipients = append(recipients, accountID)
+		}
+		notification := &models.Notification{
+			Action:    models.NotificationActionChoreUpdated,
+			AccountID: actorID,
+			ChoreID:   &chore.ID,
+		}
+		if err := s.CreateNotification(notification, recipients, chore.HouseholdID); err != nil {
+			return models.ChoreResponse{}, err
+		}
+	}
+	for _, swap := range cancelledSwaps {
+		if err := s.notifySwapParties(models.NotificationActionSwapCancelled, actorID, swap); err != nil {
+			return models.ChoreResponse{}, err
+		}
+	}
+
+	return choreResponse(chore), nil
+}
+
+func replaceChoreSchedule(tx *gorm.DB, chore *models.Chore, days []int) error {
+	if err := tx.Where("chore_id = ?", chore.ID).Delete(&models.ChoreSchedule{}).Error; err != nil {
+		return err
+	}
+	for _, day := range days {
+		if err := tx.Create(&models.ChoreSchedule{ChoreID: chore.ID, DayOfWeek: day}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceChoreRotation(tx *gorm.DB, chore *models.Chore, assignees []uuid.UUID) error {
+	if err := tx.Where("chore_id = ?", chore.ID).Delete(&models.ChoreRotation{}).Error; err != nil {
+		return err
+	}
+	for i, accountID := range assignees {
+		if err := tx.Create(&models.ChoreRotation{
+			ChoreID:       chore.ID,
+			AccountID:     accountID,
+			HouseholdID:   chore.HouseholdID,
+			RotationOrder: i,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// regenerateRecurringAssignments lays the chore's PENDING and PLANNED
+// assignments onto its current schedule and rotation. Existing rows are reused
+// in due date order, extra occurrences are planned and leftovers are cancelled.
+// Accepted swaps are carried over where they still fit and cancelled where
+// they don't. When reschedule is false only their points are refreshed. It
+// returns every account whose open assignments changed and the cancelled swaps.
+func (s *dbService) regenerateRecurringAssignments(tx *gorm.DB, chore *models.Chore, reschedule bool, actorID uuid.UUID) (map[uuid.UUID]bool, []models.ChoreSwap, error) {
+	affected := map[uuid.UUID]bool{}
+
+	var open []models.AccountChore
+	if err := tx.Where("chore_id = ? AND status IN ?", chore.ID,
+		[]models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusPlanned}).
+		Order("due_date").
+		Find(&open).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, assignment := range open {
+		affected[assignment.AccountID] = true
+	}
+
+	if !reschedule {
+		if err := tx.Model(&models.AccountChore{}).
+			Where("chore_id = ? AND status IN ?", chore.ID,
+				[]models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusPlanned}).
+			Update("points", chore.Points).Error; err != nil {
+			return nil, nil, err
+		}
+		return affected, nil, nil
+	}
+
+	var rotations []models.ChoreRotation
+	if err := tx.Where("chore_id = ?", chore.ID).Order("rotation_order").Find(&rotations).Error; err != nil {
+		return nil, nil, err
+	}
+	rule, start, err := choreRecurrence(tx, chore)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	today := now.In(start.Location())
+	days := rule.Between(start, today, today.AddDate(0, 0, s.horizonDays+1))
+	if len(days) < len(open) || len(days) == 0 {
+		days = rule.NextN(start, today, max(len(open), 1))
+	}
+
+	calendar, err := loadAvailability(tx, chore.HouseholdID, today)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	swaps, handoffs, err := acceptedSwaps(tx, open)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Keep whoever is up next at the front of the rotation if they are still in
+	// it. A turn someone swapped away is still theirs.
+	order := 0
+	if len(open) > 0 {
+		next := open[0].AccountID
+		if open[0].CoveredForID != nil {
+			next = *open[0].CoveredForID
+		}
+		if handoff, ok := handoffs[open[0].ID]; ok {
+			next = handoff.from
+		}
+		for _, rotation := range rotations {
+			if rotation.AccountID == next {
+				order = rotation.RotationOrder
+				break
+			}
+		}
+	}
+
+	strategy := strategyFor(chore)
+	previous := order - 1
+	planned := map[uuid.UUID]models.AccountChore{}
+	for i, day := range days {
+		if len(rotations) == 0 {
+			break
+		}
+		if order, err = strategy.NextSlot(tx, chore, rotations, previous, day); err != nil {
+			return nil, nil, err
+		}
+		assignment := models.AccountChore{
+			ChoreID:     chore.ID,
+			HouseholdID: chore.HouseholdID,
+		}
+		if i < len(open) {
+			assignment = open[i]
+		}
+		assignment.AccountID, assignment.CoveredForID = calendar.assignee(rotations, order, day)
+		assignment.RotationOrder = order
+		assignment.DueDate = dueDateOn(day)
+		assignment.Points = chore.Points
+		assignment.Status = models.AssignmentStatusPlanned
+		if err := tx.Save(&assignment).Error; err != nil {
+			return nil, nil, err
+		}
+		planned[assignment.ID] = assignment
+		affected[assignment.AccountID] = true
+		previous = order
+	}
+
+	// Occurrences the new schedule no longer has
+	for i := len(days); i < len(open); i++ {
+		if err := tx.Model(&open[i]).Update("status", models.AssignmentStatusCancelled).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+
+	received, cancelled, err := carryOverSwaps(tx, swaps, handoffs, open, planned, rotations, actorID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	for accountID := range received {
+		affected[accountID] = true
+	}
+
+	if _, err := promoteNextAssignment(tx, chore.ID, now); err != nil {
+		return nil, nil, err
+	}
+	return affected, cancelled, nil
+}
+
+// regenerateOneTimeAssignment moves the chore's upcoming assignment to the
+// current end date, points and, when given, the first assignee. An overdue
+// assignment has already been missed and is left as it is.
+func regenerateOneTimeAssignment(tx *gorm.DB, chore *models.Chore, assignees []uuid.UUID) (map[uuid.UUID]bool, error) {
+	affected := map[uuid.UUID]bool{}
+
+	var open []models.AccountChore
+	if err := tx.Where("chore_id = ? AND status IN ?", chore.ID, swappableStatuses).
+		Find(&open).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, assignment := range open {
+		affected[assignment.AccountID] = true
+		if len(assignees) > 0 {
+			assignment.AccountID = assignees[0]
+		}
+		assignment.DueDate = chore.EndDate
+		assignment.Points = chore.Points
+		if assignment.DueDate.After(now) {
+			assignment.Status = models.AssignmentStatusPending
+		}
+		if err := tx.Save(&assignment).Error; err != nil {
+			return nil, err
+		}
+		affected[assignment.AccountID] = true
+	}
+	return affected, nil
+}
+
+// ArchiveChore stops a chore from planning new assignments and cancels the
+// ones that are still open. Completed assignments are kept so points and
+// leaderboards don't change. Archiving an archived chore does nothing.
+func (s *dbService) ArchiveChore(choreID uuid.UUID, actorID uuid.UUID) (models.ChoreResponse, error) {
+	var chore models.Chore
+	var cancelled []models.AccountChore
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&chore, choreID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrChoreNotFound
+			}
+			return err
+		}
+		if chore.ArchivedAt != nil {
+			return nil
+		}
+		var err error
+		cancelled, err = archiveChore(tx, &chore, actorID, time.Now())
+		return err
+	})
+	if err != nil {
+		return models.ChoreResponse{}, err
+	}
+
+	recipients := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	for _, assignment := range cancelled {
+		if !seen[assignment.AccountID] {
+			seen[assignment.AccountID] = true
+			recipients = append(recipients, assignment.AccountID)
+		}
+	}
+	if len(recipients) > 0 {
+		notification := &models.Notification{
+			Action:    models.NotificationActionChoreArchived,
+			AccountID: actorID,
+			ChoreID:   &chore.ID,
+		}
+		if err := s.CreateNotification(notification, recipients, chore.HouseholdID); err != nil {
+			return models.ChoreResponse{}, err
+		}
+	}
+
+	return choreResponse(chore), nil
+}
+
+// archiveChore marks a locked chore archived and cancels its open assignments,
+// returning them so their assignees can be told.
+func archiveChore(tx *gorm.DB, chore *models.Chore, actorID uuid.UUID, now time.Time) ([]models.AccountChore, error) {
+	before := choreSnapshot(*chore)
+	chore.ArchivedAt = &now
+	if err := tx.Model(chore).Update("archived_at", now).Error; err != nil {
+		return nil, err
+	}
+
+	var cancelled []models.AccountChore
+	if err := tx.Where("chore_id = ? AND status IN ?", chore.ID, openAssignmentStatuses).
+		Find(&cancelled).Error; err != nil {
+		return nil, err
+	}
+	for i := range cancelled {
+		assignmentBefore := assignmentSnapshot(cancelled[i])
+		cancelled[i].Status = models.AssignmentStatusCancelled
+		if err := tx.Model(&cancelled[i]).Update("status", models.AssignmentStatusCancelled).Error; err != nil {
+			return nil, err
+		}
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: chore.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionChoreArchived,
+			EntityType:  models.AuditEntityAccountChore,
+			EntityID:    cancelled[i].ID,
+		}, assignmentBefore, assignmentSnapshot(cancelled[i])); err != nil {
+			return nil, err
+		}
+	}
+
+	return cancelled, recordAudit(tx, models.AuditLog{
+		HouseholdID: chore.HouseholdID,
+		ActorID:     &actorID,
+		Action:      models.AuditActionChoreArchived,
+		EntityType:  models.AuditEntityChore,
+		EntityID:    chore.ID,
+	}, before, choreSnapshot(*chore))
+}
+
+// RestoreChore brings an archived chore back. Recurring chores plan their
+// upcoming occurrences again, one-time chores reopen their cancelled assignment.
+// Cancelled occurrences of recurring chores stay cancelled.
+func (s *dbService) RestoreChore(choreID uuid.UUID, actorID uuid.UUID) (models.ChoreResponse, error) {
+	var chore models.Chore
+	var promoted *models.AccountChore
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&chore, choreID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrChoreNotFound
+			}
+			return err
+		}
+		if chore.ArchivedAt == nil {
+			return nil
+		}
+		before := choreSnapshot(chore)
+
+		chore.ArchivedAt = nil
+		if err := tx.Model(&chore).Update("archived_at", nil).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if chore.Type == models.ChoreTypeRecurring {
+			if _, err := s.extendAssignments(tx, &chore, now.AddDate(0, 0, s.horizonDays), &actorID); err != nil {
+				return err
+			}
+			next, err := promoteNextAssignment(tx, chore.ID, now)
+			if err != nil {
+				return err
+			}
+			promoted = next
+		} else {
+			var assignment models.AccountChore
+			err := tx.Where("chore_id = ? AND status = ?", chore.ID, models.AssignmentStatusCancelled).
+				Order("due_date DESC").
+				First(&assignment).Error
+			if err == nil {
+				// The scheduler marks it overdue again if its due date has passed
+				assignment.Status = models.AssignmentStatusPending
+				if err := tx.Model(&assignment).Update("status", models.AssignmentStatusPending).Error; err != nil {
+					return err
+				}
+				promoted = &assignment
+			} else if err != gorm.ErrRecordNotFound {
+				return err
+			}
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: chore.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionChoreRestored,
+			EntityType:  models.AuditEntityChore,
+			EntityID:    chore.ID,
+		}, before, choreSnapshot(chore))
+	})
+	if err != nil {
+		return models.ChoreResponse{}, err
+	}
+
+	if promoted != nil {
+		if err := s.notifyHousehold(models.NotificationActionChoreAssigned, *promoted); err != nil {
+			return models.ChoreResponse{}, err
+		}
+	}
+
+	return choreResponse(chore), nil
+}
+
+func (s *dbService) GetArchivedChores(householdID uuid.UUID) ([]models.ChoreResponse, error) {
+	var chores []models.Chore
+	if err := s.db.Where("household_id = ? AND archived_at IS NOT NULL", householdID).
+		Order("archived_at DESC").
+		Find(&chores).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]models.ChoreResponse, len(chores))
+	for i, chore := range chores {
+		response[i] = choreResponse(chore)
+	}
+	return response, nil
+}
+
+func choreResponse(chore models.Chore) models.ChoreResponse {
+	return models.ChoreResponse{
+		ID:               chore.ID,
+		Title:            chore.Title,
+		Description:      chore.Description,
+		Type:             chore.Type,
+		Recurrence:       chore.Recurrence,
+		HouseholdID:      chore.HouseholdID,
+		CreatedAt:        chore.CreatedAt,
+		ArchivedAt:       chore.ArchivedAt,
+		Strategy:         chore.Strategy,
+		EstimatedMinutes: chore.EstimatedMinutes,
+		RequireReview:    chore.RequireReview,
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"chore-share/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateChoreNeedsAnAssignee(t *testing.T) {
+	// Checked before anything touches the database
+	s := &dbService{horizonDays: DefaultHorizonDays}
+	for _, assignees := range [][]uuid.UUID{nil, {}} {
+		if err := s.CreateChore(&models.Chore{Title: "Dishes"}, assignees, nil, uuid.New()); err != ErrNoAssignees {
+			t.Errorf("CreateChore(%v) error = %v, want %v", assignees, err, ErrNoAssignees)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"chore-share/models"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNothingToUndo      = errors.New("assignment has no completion to undo")
+	ErrUndoWindowPassed   = errors.New("the completion can no longer be undone")
+	ErrNotCompleter       = errors.New("only the member who completed the chore can undo it")
+	ErrCompletionReviewed = errors.New("a reviewed completion cannot be undone")
+)
+
+// completionEffects is what a completion changed besides the assignment itself.
+type completionEffects struct {
+	Rotation  []assignmentState `json:"rotation"`
+	Unblocked []uuid.UUID       `json:"unblocked"`
+}
+
+// assignmentState is an assignment as it was before the completion, or just
+// its ID when the completion created it.
+type assignmentState struct {
+	ID           uuid.UUID               `json:"id"`
+	Created      bool                    `json:"created,omitempty"`
+	Status       models.AssignmentStatus `json:"status,omitempty"`
+	DueDate      time.Time               `json:"dueDate"`
+	AccountID    uuid.UUID               `json:"accountId"`
+	CoveredForID *uuid.UUID              `json:"coveredForId"`
+}
+
+// Completing a chore only moves PENDING and PLANNED assignments of its rotation.
+var rotationStatuses = []models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusPlanned}
+
+func rotationState(tx *gorm.DB, choreID uuid.UUID) (map[uuid.UUID]models.AccountChore, error) {
+	var assignments []models.AccountChore
+	if err := tx.Where("chore_id = ? AND status IN ?", choreID, rotationStatuses).
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	state := make(map[uuid.UUID]models.AccountChore, len(assignments))
+	for _, assignment := range assignments {
+		state[assignment.ID] = assignment
+	}
+	return state, nil
+}
+
+// rotationChanges compares the rotation before and after a completion.
+func rotationChanges(before map[uuid.UUID]models.AccountChore, after map[uuid.UUID]models.AccountChore) []assignmentState {
+	var changes []assignmentState
+	for id, current := range after {
+		previous, existed := before[id]
+		if !existed {
+			changes = append(changes, assignmentState{ID: id, Created: true})
+			continue
+		}
+		if previous.Status != current.Status || !previous.DueDate.Equal(current.DueDate) ||
+			previous.AccountID != current.AccountID || !equalIDs(previous.CoveredForID, current.CoveredForID) {
+			changes = append(changes, assignmentState{
+				ID:           id,
+				Status:       previous.Status,
+				DueDate:      previous.DueDate,
+				AccountID:    previous.AccountID,
+				CoveredForID: previous.CoveredForID,
+			})
+		}
+	}
+	return changes
+}
+
+func equalIDs(a *uuid.UUID, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// recordCompletion keeps what the completion changed for UndoChoreCompletion.
+func recordCompletion(tx *gorm.DB, completionID uuid.UUID, assignment models.AccountChore, previousStatus models.AssignmentStatus, actorID uuid.UUID, effects completionEffects) error {
+	data, err := json.Marshal(effects)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&models.ChoreCompletion{
+		ID:             completionID,
+		AccountChoreID: assignment.ID,
+		HouseholdID:    assignment.HouseholdID,
+		CompletedByID:  actorID,
+		CompletedAt:    *assignment.CompletedAt,
+		PreviousStatus: previousStatus,
+		Effects:        data,
+	}).Error
+}
+
+// UndoChoreCompletion reopens an assignment completed by mistake, as long as
+// it is still within window of the completion and nobody has reviewed it. The
+// rotation is put back the way it was, except for assignments that have moved
+// on since, and the completion's notifications are marked as retracted.
+func (s *dbService) UndoChoreCompletion(accountChoreID uuid.UUID, actorID uuid.UUID, window time.Duration) error {
+	var assignment models.AccountChore
+	var photoKeys []string
+	now := time.Now()
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", accountChoreID).
+			First(&assignment).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrAssignmentNotFound
+			}
+			return err
+		}
+		if assignment.Status != models.AssignmentStatusCompleted && assignment.Status != models.AssignmentStatusPendingReview {
+			return ErrNothingToUndo
+		}
+
+		var completions []models.ChoreCompletion
+		if err := tx.Where("account_chore_id = ? AND undone_at IS NULL", assignment.ID).
+			Order("completed_at DESC").
+			Limit(1).
+			Find(&completions).Error; err != nil {
+			return err
+		}
+		if len(completions) == 0 || assignment.CompletedAt == nil || !completions[0].CompletedAt.Equal(*assignment.CompletedAt) {
+			return ErrNothingToUndo
+		}
+		completion := completions[0]
+		if completion.CompletedByID != actorID {
+			return ErrNotCompleter
+		}
+		if now.Sub(completion.CompletedAt) > window {
+			return ErrUndoWindowPassed
+		}
+
+		var reviews int64
+		if err := tx.Model(&models.ChoreReview{}).
+			Where("account_chore_id = ? AND created_at >= ?", assignment.ID, completion.CompletedAt).
+			Count(&reviews).Error; err != nil {
+			return err
+		}
+		if reviews > 0 {
+			return ErrCompletionReviewed
+		}
+
+		before := assignmentSnapshot(assignment)
+		assignment.Status = completion.PreviousStatus
+		assignment.CompletedAt = nil
+		if err := tx.Model(&assignment).Updates(map[string]interface{}{
+			"status":       assignment.Status,
+			"completed_at": nil,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := revertCompletion(tx, completion, now); err != nil {
+			return err
+		}
+
+		// Photos were proof of this completion
+		var photos []models.ChorePhoto
+		if err := tx.Where("completion_id = ?", completion.ID).Find(&photos).Error; err != nil {
+			return err
+		}
+		for _, photo := range photos {
+			photoKeys = append(photoKeys, photo.Key, photo.ThumbnailKey)
+		}
+		if len(photos) > 0 {
+			if err := tx.Delete(&photos).Error; err != nil {
+				return err
+			}
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: assignment.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionCompletionUndone,
+			EntityType:  models.AuditEntityAccountChore,
+			EntityID:    assignment.ID,
+		}, before, assignmentSnapshot(assignment))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteBlobs(photoKeys)
+
+	var householdMembers []uuid.UUID
+	if err := s.db.Model(&models.AccountHousehold{}).
+		Where("household_id = ?", assignment.HouseholdID).
+		Pluck("account_id", &householdMembers).Error; err != nil {
+		return err
+	}
+	return s.CreateNotification(&models.Notification{
+		Action:         models.NotificationActionCompletionUndone,
+		AccountID:      actorID,
+		ChoreID:        &assignment.ChoreID,
+		AccountChoreID: &assignment.ID,
+	}, householdMembers, assignment.HouseholdID)
+}
+
+// revertCompletion puts the rotation back the way it was before the completion,
+// retracts the notifications it sent and marks it undone. The assignment
+// itself is left to the caller.
+func revertCompletion(tx *gorm.DB, completion models.ChoreCompletion, now time.Time) error {
+	var effects completionEffects
+	if len(completion.Effects) > 0 {
+		if err := json.Unmarshal(completion.Effects, &effects); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range effects.Rotation {
+		if err := revertRotationChange(tx, change); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(&models.Notification{}).
+		Where("completion_id = ? AND retracted_at IS NULL", completion.ID).
+		Update("retracted_at", now).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&completion).Update("undone_at", now).Error
+}
+
+// revertRotationChange puts back an assignment the completion created or
+// moved. Assignments that have since been completed, swapped into another
+// state or cancelled are left as they are.
+func revertRotationChange(tx *gorm.DB, change assignmentState) error {
+	var current models.AccountChore
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, change.ID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if !slices.Contains(rotationStatuses, current.Status) {
+		return nil
+	}
+
+	if change.Created {
+		return tx.Model(&current).Update("status", models.AssignmentStatusCancelled).Error
+	}
+	return tx.Model(&current).Updates(map[string]interface{}{
+		"status":         change.Status,
+		"due_date":       change.DueDate,
+		"account_id":     change.AccountID,
+		"covered_for_id": change.CoveredForID,
+	}).Error
+}
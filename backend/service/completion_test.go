@@ -0,0 +1,68 @@
+package service
+
+import (
+	"bytes"
+	"chore-share/models"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func testPhoto(t *testing.T) PhotoUpload {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	return PhotoUpload{Data: buf.Bytes()}
+}
+
+func TestUndoCompletionRemovesPhotosAndNotifications(t *testing.T) {
+	s, store := newTestService(t)
+	alice := createTestAccount(t, s, "Alice")
+	bob := createTestAccount(t, s, "Bob")
+	createTestHousehold(t, s, alice, bob)
+	_, assignment := createTestChore(t, s, models.Chore{}, alice)
+
+	if err := s.CompleteChore(assignment.ID, alice, []PhotoUpload{testPhoto(t)}); err != nil {
+		t.Fatal(err)
+	}
+	photos, err := s.GetAssignmentPhotos(assignment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(photos) != 1 || store.len() != 2 {
+		t.Fatalf("got %d photos and %d blobs after completing, want 1 and 2", len(photos), store.len())
+	}
+
+	if err := s.UndoChoreCompletion(assignment.ID, alice, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	photos, err = s.GetAssignmentPhotos(assignment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(photos) != 0 || store.len() != 0 {
+		t.Errorf("got %d photos and %d blobs after undoing, want none", len(photos), store.len())
+	}
+
+	var live int64
+	if err := s.db.Model(&models.Notification{}).
+		Where("account_chore_id = ? AND action = ? AND retracted_at IS NULL", assignment.ID, models.NotificationActionChoreCompleted).
+		Count(&live).Error; err != nil {
+		t.Fatal(err)
+	}
+	if live != 0 {
+		t.Errorf("%d completion notifications are still live after undoing", live)
+	}
+
+	var reopened models.AccountChore
+	if err := s.db.First(&reopened, assignment.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Status != models.AssignmentStatusPending || reopened.CompletedAt != nil {
+		t.Errorf("assignment is %s with completedAt %v, want PENDING and nil", reopened.Status, reopened.CompletedAt)
+	}
+}
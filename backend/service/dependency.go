@@ -0,0 +1,324 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidDependency = errors.New("prerequisites must be other active chores in the same household")
+	ErrDependencyCycle   = errors.New("chore dependencies cannot form a cycle")
+	ErrAssignmentBlocked = errors.New("assignment is waiting for a prerequisite chore")
+)
+
+// Prerequisite occurrences in these statuses no longer block their dependents.
+// Work waiting for review has been done, even if it doesn't earn points yet.
+var prerequisiteDoneStatuses = []models.AssignmentStatus{
+	models.AssignmentStatusCompleted,
+	models.AssignmentStatusPendingReview,
+}
+
+// UpdateChoreDependencies replaces the chores a chore waits for.
+func (s *dbService) UpdateChoreDependencies(choreID uuid.UUID, prerequisiteIDs []uuid.UUID, actorID uuid.UUID) (models.ChoreDependenciesResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var chore models.Chore
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&chore, choreID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrChoreNotFound
+			}
+			return err
+		}
+		if chore.ArchivedAt != nil {
+			return ErrChoreArchived
+		}
+
+		seen := map[uuid.UUID]bool{}
+		var unique []uuid.UUID
+		for _, id := range prerequisiteIDs {
+			if id == chore.ID {
+				return ErrInvalidDependency
+			}
+			if !seen[id] {
+				seen[id] = true
+				unique = append(unique, id)
+			}
+		}
+
+		if len(unique) > 0 {
+			var count int64
+			if err := tx.Model(&models.Chore{}).
+				Where("id IN ? AND household_id = ? AND archived_at IS NULL", unique, chore.HouseholdID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if int(count) != len(unique) {
+				return ErrInvalidDependency
+			}
+
+			graph, err := householdDependencies(tx, chore.HouseholdID)
+			if err != nil {
+				return err
+			}
+			for _, id := range unique {
+				if dependsOn(graph, id, chore.ID, map[uuid.UUID]bool{}) {
+					return ErrDependencyCycle
+				}
+			}
+		}
+
+		var before []uuid.UUID
+		if err := tx.Model(&models.ChoreDependency{}).
+			Where("chore_id = ?", chore.ID).
+			Pluck("prerequisite_id", &before).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("chore_id = ?", chore.ID).Delete(&models.ChoreDependency{}).Error; err != nil {
+			return err
+		}
+		for _, id := range unique {
+			if err := tx.Create(&models.ChoreDependency{ChoreID: chore.ID, PrerequisiteID: id}).Error; err != nil {
+				return err
+			}
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: chore.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionChoreUpdated,
+			EntityType:  models.AuditEntityChore,
+			EntityID:    chore.ID,
+		}, map[string]interface{}{"prerequisiteIds": before}, map[string]interface{}{"prerequisiteIds": unique})
+	})
+	if err != nil {
+		return models.ChoreDependenciesResponse{}, err
+	}
+	return s.GetChoreDependencies(choreID)
+}
+
+func (s *dbService) GetChoreDependencies(choreID uuid.UUID) (models.ChoreDependenciesResponse, error) {
+	var chore models.Chore
+	if err := s.db.First(&chore, choreID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return models.ChoreDependenciesResponse{}, ErrChoreNotFound
+		}
+		return models.ChoreDependenciesResponse{}, err
+	}
+
+	var dependencies []models.ChoreDependency
+	if err := s.db.Preload("Chore").Preload("Prerequisite").
+		Where("chore_id = ? OR prerequisite_id = ?", chore.ID, chore.ID).
+		Order("created_at").
+		Find(&dependencies).Error; err != nil {
+		return models.ChoreDependenciesResponse{}, err
+	}
+
+	response := models.ChoreDependenciesResponse{
+		ChoreID:       chore.ID,
+		Prerequisites: []models.ChoreResponse{},
+		Dependents:    []models.ChoreResponse{},
+	}
+	for _, dependency := range dependencies {
+		if dependency.ChoreID == chore.ID {
+			response.Prerequisites = append(response.Prerequisites, choreResponse(dependency.Prerequisite))
+		} else {
+			response.Dependents = append(response.Dependents, choreResponse(dependency.Chore))
+		}
+	}
+	return response, nil
+}
+
+// householdDependencies maps each chore of the household to its prerequisites.
+func householdDependencies(tx *gorm.DB, householdID uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	var dependencies []models.ChoreDependency
+	if err := tx.Joins("JOIN chores ON chores.id = chore_dependencies.chore_id").
+		Where("chores.household_id = ?", householdID).
+		Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+
+	graph := map[uuid.UUID][]uuid.UUID{}
+	for _, dependency := range dependencies {
+		graph[dependency.ChoreID] = append(graph[dependency.ChoreID], dependency.PrerequisiteID)
+	}
+	return graph, nil
+}
+
+// dependsOn reports whether chore waits for target, directly or through other
+// prerequisites.
+func dependsOn(graph map[uuid.UUID][]uuid.UUID, chore uuid.UUID, target uuid.UUID, visited map[uuid.UUID]bool) bool {
+	if chore == target {
+		return true
+	}
+	if visited[chore] {
+		return false
+	}
+	visited[chore] = true
+	for _, prerequisite := range graph[chore] {
+		if dependsOn(graph, prerequisite, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// prerequisiteOccurrences finds, for each prerequisite, the latest occurrence
+// due on or before the assignment. Prerequisites without one don't hold it up.
+func prerequisiteOccurrences(tx *gorm.DB, assignment models.AccountChore, prerequisiteIDs []uuid.UUID) ([]models.AccountChore, error) {
+	var occurrences []models.AccountChore
+	for _, prerequisiteID := range prerequisiteIDs {
+		var occurrence []models.AccountChore
+		if err := tx.Preload("Chore").
+			Where("chore_id = ? AND status <> ? AND due_date <= ?", prerequisiteID, models.AssignmentStatusCancelled, assignment.DueDate).
+			Order("due_date DESC").
+			Limit(1).
+			Find(&occurrence).Error; err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, occurrence...)
+	}
+	return occurrences, nil
+}
+
+// blockingPrerequisites returns the prerequisite occurrences the assignment is
+// still waiting for.
+func blockingPrerequisites(tx *gorm.DB, assignment models.AccountChore) ([]models.AccountChore, error) {
+	prerequisites, err := loadPrerequisites(tx, []uuid.UUID{assignment.ChoreID})
+	if err != nil {
+		return nil, err
+	}
+	occurrences, err := prerequisiteOccurrences(tx, assignment, prerequisites[assignment.ChoreID])
+	if err != nil {
+		return nil, err
+	}
+	return unfinished(occurrences), nil
+}
+
+func unfinished(occurrences []models.AccountChore) []models.AccountChore {
+	var blocking []models.AccountChore
+	for _, occurrence := range occurrences {
+		if !slices.Contains(prerequisiteDoneStatuses, occurrence.Status) {
+			blocking = append(blocking, occurrence)
+		}
+	}
+	return blocking
+}
+
+// unblockedBy finds the open assignments that were waiting for the completed
+// occurrence and have nothing else left to wait for. Upcoming PLANNED ones are
+// included, so their assignees know the way is clear before their turn starts.
+// Nothing needs re-blocking when the completion is undone or rejected: blocking
+// is worked out from the prerequisite's status, and the CHORE_UNBLOCKED
+// notifications are retracted along with the completion they link to.
+func unblockedBy(tx *gorm.DB, completed models.AccountChore) ([]models.AccountChore, error) {
+	var dependentIDs []uuid.UUID
+	if err := tx.Model(&models.ChoreDependency{}).
+		Where("prerequisite_id = ?", completed.ChoreID).
+		Pluck("chore_id", &dependentIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(dependentIDs) == 0 {
+		return nil, nil
+	}
+
+	var candidates []models.AccountChore
+	if err := tx.Where("chore_id IN ? AND status IN ? AND due_date >= ?",
+		dependentIDs,
+		openAssignmentStatuses,
+		completed.DueDate).
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	prerequisites, err := loadPrerequisites(tx, dependentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var unblocked []models.AccountChore
+	for _, candidate := range candidates {
+		occurrences, err := prerequisiteOccurrences(tx, candidate, prerequisites[candidate.ChoreID])
+		if err != nil {
+			return nil, err
+		}
+		waitedForCompleted := false
+		for _, occurrence := range occurrences {
+			if occurrence.ID == completed.ID {
+				waitedForCompleted = true
+			}
+		}
+		if waitedForCompleted && len(unfinished(occurrences)) == 0 {
+			unblocked = append(unblocked, candidate)
+		}
+	}
+	return unblocked, nil
+}
+
+// notifyUnblocked tells each assignee that their assignment can now be done,
+// linking the notification to the completion that unblocked it.
+func (s *dbService) notifyUnblocked(assignments []models.AccountChore, actorID uuid.UUID, completionID uuid.UUID) error {
+	for _, assignment := range assignments {
+		notification := &models.Notification{
+			Action:         models.NotificationActionChoreUnblocked,
+			AccountID:      actorID,
+			ChoreID:        &assignment.ChoreID,
+			AccountChoreID: &assignment.ID,
+			CompletionID:   &completionID,
+		}
+		if err := s.CreateNotification(notification, []uuid.UUID{assignment.AccountID}, assignment.HouseholdID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadPrerequisites(tx *gorm.DB, choreIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	var dependencies []models.ChoreDependency
+	if err := tx.Where("chore_id IN ?", choreIDs).Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+
+	prerequisites := map[uuid.UUID][]uuid.UUID{}
+	for _, dependency := range dependencies {
+		prerequisites[dependency.ChoreID] = append(prerequisites[dependency.ChoreID], dependency.PrerequisiteID)
+	}
+	return prerequisites, nil
+}
+
+// annotateBlocked fills in what each open assignment in the list is waiting for.
+func (s *dbService) annotateBlocked(assignments []models.AccountChoreResponse) error {
+	var choreIDs []uuid.UUID
+	for _, assignment := range assignments {
+		choreIDs = append(choreIDs, assignment.ChoreID)
+	}
+	if len(choreIDs) == 0 {
+		return nil
+	}
+	prerequisites, err := loadPrerequisites(s.db, choreIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, assignment := range assignments {
+		if len(prerequisites[assignment.ChoreID]) == 0 || !slices.Contains(openAssignmentStatuses, assignment.Status) {
+			continue
+		}
+		occurrences, err := prerequisiteOccurrences(s.db, models.AccountChore{DueDate: assignment.DueDate}, prerequisites[assignment.ChoreID])
+		if err != nil {
+			return err
+		}
+		for _, occurrence := range unfinished(occurrences) {
+			assignments[i].BlockedBy = append(assignments[i].BlockedBy, models.ChoreInfo{
+				ChoreID:        occurrence.ChoreID,
+				AccountChoreID: occurrence.ID,
+				Title:          occurrence.Chore.Title,
+				DueDate:        occurrence.DueDate,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"chore-share/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// liveUnblocked counts the CHORE_UNBLOCKED notifications for the assignment
+// that haven't been retracted.
+func liveUnblocked(t *testing.T, s *dbService, assignmentID uuid.UUID) int64 {
+	t.Helper()
+	var count int64
+	if err := s.db.Model(&models.Notification{}).
+		Where("account_chore_id = ? AND action = ? AND retracted_at IS NULL", assignmentID, models.NotificationActionChoreUnblocked).
+		Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestRejectedPrerequisiteBlocksDependentAgain(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createTestAccount(t, s, "Alice")
+	bob := createTestAccount(t, s, "Bob")
+	householdID := createTestHousehold(t, s, alice, bob)
+
+	_, prerequisite := createTestChore(t, s, models.Chore{
+		Title:         "Wash the dishes",
+		HouseholdID:   householdID,
+		EndDate:       time.Now().Add(time.Hour),
+		RequireReview: true,
+	}, alice)
+	dependentChore, dependent := createTestChore(t, s, models.Chore{
+		Title:       "Dry the dishes",
+		HouseholdID: householdID,
+		EndDate:     time.Now().Add(48 * time.Hour),
+	}, bob)
+	if _, err := s.UpdateChoreDependencies(dependentChore.ID, []uuid.UUID{prerequisite.ChoreID}, alice); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.CompleteChore(dependent.ID, bob, nil); err != ErrAssignmentBlocked {
+		t.Fatalf("completing before the prerequisite: error = %v, want %v", err, ErrAssignmentBlocked)
+	}
+
+	if err := s.CompleteChore(prerequisite.ID, alice, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := liveUnblocked(t, s, dependent.ID); got != 1 {
+		t.Fatalf("%d live unblocked notifications after the prerequisite was done, want 1", got)
+	}
+
+	if err := s.CreateChoreReview(&models.ChoreReview{
+		AccountChoreID: prerequisite.ID,
+		HouseholdID:    householdID,
+		ReviewerID:     bob,
+		Review:         "Still greasy",
+		ReviewerStatus: models.ReviewStatusRejected,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := liveUnblocked(t, s, dependent.ID); got != 0 {
+		t.Errorf("%d live unblocked notifications after the rejection, want 0", got)
+	}
+	if err := s.CompleteChore(dependent.ID, bob, nil); err != ErrAssignmentBlocked {
+		t.Errorf("completing after the rejection: error = %v, want %v", err, ErrAssignmentBlocked)
+	}
+}
+
+func TestPlannedDependentIsToldWhenUnblocked(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createTestAccount(t, s, "Alice")
+	bob := createTestAccount(t, s, "Bob")
+	householdID := createTestHousehold(t, s, alice, bob)
+
+	_, prerequisite := createTestChore(t, s, models.Chore{
+		HouseholdID: householdID,
+		EndDate:     time.Now().Add(time.Hour),
+	}, alice)
+	dependentChore, dependent := createTestChore(t, s, models.Chore{
+		HouseholdID: householdID,
+		EndDate:     time.Now().Add(48 * time.Hour),
+	}, bob)
+	if _, err := s.UpdateChoreDependencies(dependentChore.ID, []uuid.UUID{prerequisite.ChoreID}, alice); err != nil {
+		t.Fatal(err)
+	}
+	// An upcoming turn that hasn't been handed out yet
+	if err := s.db.Model(&dependent).Update("status", models.AssignmentStatusPlanned).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.CompleteChore(prerequisite.ID, alice, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := liveUnblocked(t, s, dependent.ID); got != 1 {
+		t.Errorf("%d unblocked notifications for the planned assignment, want 1", got)
+	}
+}
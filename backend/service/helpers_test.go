@@ -0,0 +1,112 @@
+package service
+
+import (
+	"chore-share/models"
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tests that need Postgres run against the database in
+// CHORE_SHARE_TEST_DATABASE_URL and are skipped when it isn't set. Every test
+// creates its own accounts and households, so they can share a database.
+func newTestService(t *testing.T) (*dbService, *memoryStore) {
+	t.Helper()
+	url := os.Getenv("CHORE_SHARE_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("CHORE_SHARE_TEST_DATABASE_URL is not set")
+	}
+	store := &memoryStore{blobs: map[string][]byte{}}
+	return NewDBService(url, store, 0).(*dbService), store
+}
+
+// memoryStore is a BlobStore that keeps uploads in memory.
+type memoryStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func (m *memoryStore) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.blobs[key] = data
+	return nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.blobs, key)
+	return nil
+}
+
+func (m *memoryStore) SignedURL(key string, ttl time.Duration) (string, error) {
+	return "memory://" + key, nil
+}
+
+func (m *memoryStore) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.blobs)
+}
+
+func createTestAccount(t *testing.T, s *dbService, name string) uuid.UUID {
+	t.Helper()
+	account := models.Account{Name: name, GoogleId: "test:" + uuid.NewString()}
+	if _, err := s.CreateAccount(&account); err != nil {
+		t.Fatal(err)
+	}
+	return account.ID
+}
+
+// createTestHousehold creates a household owned by the first account, with
+// the others as plain members.
+func createTestHousehold(t *testing.T, s *dbService, ownerID uuid.UUID, memberIDs ...uuid.UUID) uuid.UUID {
+	t.Helper()
+	household := models.Household{Name: "Test household", Password: "password"}
+	if err := s.CreateHousehold(&household, ownerID); err != nil {
+		t.Fatal(err)
+	}
+	for _, memberID := range memberIDs {
+		if err := s.db.Create(&models.AccountHousehold{
+			AccountID:   memberID,
+			HouseholdID: household.ID,
+			Role:        models.HouseholdRoleMember,
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return household.ID
+}
+
+// createTestChore creates a chore with the given assignees and returns it
+// along with its first open assignment.
+func createTestChore(t *testing.T, s *dbService, chore models.Chore, assignees ...uuid.UUID) (models.Chore, models.AccountChore) {
+	t.Helper()
+	if chore.Title == "" {
+		chore.Title = "Test chore"
+	}
+	if chore.Type == "" {
+		chore.Type = models.ChoreTypeOneTime
+	}
+	if chore.EndDate.IsZero() {
+		chore.EndDate = time.Now().Add(24 * time.Hour)
+	}
+	if chore.Points == 0 {
+		chore.Points = 10
+	}
+	if err := s.CreateChore(&chore, assignees, nil, assignees[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	var assignment models.AccountChore
+	if err := s.db.Where("chore_id = ? AND status = ?", chore.ID, models.AssignmentStatusPending).
+		First(&assignment).Error; err != nil {
+		t.Fatal(err)
+	}
+	return chore, assignment
+}
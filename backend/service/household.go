@@ -0,0 +1,160 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidHouseholdPassword = errors.New("password cannot be empty")
+
+func (s *dbService) UpdateHousehold(householdID uuid.UUID, update models.UpdateHouseholdRequestBody, actorID uuid.UUID) (models.HouseholdResponse, error) {
+	updates := map[string]interface{}{}
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return models.HouseholdResponse{}, ErrInvalidName
+		}
+		updates["name"] = name
+	}
+	if update.Password != nil {
+		if *update.Password == "" {
+			return models.HouseholdResponse{}, ErrInvalidHouseholdPassword
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(*update.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return models.HouseholdResponse{}, err
+		}
+		updates["password"] = string(hash)
+	}
+	if update.Timezone != nil {
+		if !validTimezone(*update.Timezone) {
+			return models.HouseholdResponse{}, ErrInvalidTimezone
+		}
+		updates["timezone"] = *update.Timezone
+	}
+
+	var household models.Household
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&household, householdID).Error; err != nil {
+			return err
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		// Only record that the password changed, never the hash
+		before := map[string]interface{}{"name": household.Name, "timezone": household.Timezone}
+		after := map[string]interface{}{"name": household.Name, "timezone": household.Timezone}
+		for _, field := range []string{"name", "timezone"} {
+			if value, ok := updates[field]; ok {
+				after[field] = value
+			}
+		}
+		if _, ok := updates["password"]; ok {
+			after["passwordChanged"] = true
+		}
+
+		updates["updated_at"] = time.Now()
+		if err := tx.Model(&household).Updates(updates).Error; err != nil {
+			return err
+		}
+		household.Name = after["name"].(string)
+		household.Timezone = after["timezone"].(string)
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: householdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionHouseholdUpdated,
+			EntityType:  models.AuditEntityHousehold,
+			EntityID:    householdID,
+		}, before, after)
+	})
+	if err != nil {
+		return models.HouseholdResponse{}, err
+	}
+	return models.HouseholdResponse{
+		ID:       household.ID,
+		Name:     household.Name,
+		Timezone: household.Timezone,
+	}, nil
+}
+
+// DeleteHousehold archives the household in a single transaction. Its chores
+// are archived and their open assignments cancelled, so the scheduler stops
+// working on them. Chores, assignments, transactions and notifications stay in
+// place for history but are no longer reachable since membership checks skip
+// archived households, and any outstanding invites are revoked.
+func (s *dbService) DeleteHousehold(householdID uuid.UUID, actorID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return deleteHousehold(tx, householdID, actorID)
+	})
+}
+
+func deleteHousehold(tx *gorm.DB, householdID uuid.UUID, actorID uuid.UUID) error {
+	var householdMembers []uuid.UUID
+	if err := tx.Model(&models.AccountHousehold{}).
+		Where("household_id = ?", householdID).
+		Pluck("account_id", &householdMembers).Error; err != nil {
+		return err
+	}
+
+	notification := models.Notification{
+		Action:      models.NotificationActionHouseholdDeleted,
+		AccountID:   actorID,
+		HouseholdID: householdID,
+	}
+	if err := tx.Create(&notification).Error; err != nil {
+		return err
+	}
+	for _, recipientID := range householdMembers {
+		if err := tx.Create(&models.AccountNotification{
+			NotificationID: notification.ID,
+			AccountID:      recipientID,
+			HouseholdID:    householdID,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	if err := tx.Model(&models.HouseholdInvite{}).
+		Where("household_id = ? AND revoked_at IS NULL", householdID).
+		Update("revoked_at", now).Error; err != nil {
+		return err
+	}
+
+	var chores []models.Chore
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("household_id = ? AND archived_at IS NULL", householdID).
+		Find(&chores).Error; err != nil {
+		return err
+	}
+	for i := range chores {
+		if _, err := archiveChore(tx, &chores[i], actorID, now); err != nil {
+			return err
+		}
+	}
+
+	var household models.Household
+	if err := tx.First(&household, householdID).Error; err != nil {
+		return err
+	}
+	if err := recordAudit(tx, models.AuditLog{
+		HouseholdID: householdID,
+		ActorID:     &actorID,
+		Action:      models.AuditActionHouseholdDeleted,
+		EntityType:  models.AuditEntityHousehold,
+		EntityID:    householdID,
+	}, map[string]interface{}{"name": household.Name}, nil); err != nil {
+		return err
+	}
+
+	return tx.Delete(&models.Household{ID: householdID}).Error
+}
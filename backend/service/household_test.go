@@ -0,0 +1,39 @@
+package service
+
+import (
+	"chore-share/models"
+	"testing"
+)
+
+func TestFormerMemberSeesHouseholdDeletion(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createTestAccount(t, s, "Alice")
+	bob := createTestAccount(t, s, "Bob")
+	householdID := createTestHousehold(t, s, alice, bob)
+
+	if err := s.DeleteHousehold(householdID, alice); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.GetHouseholdMembership(bob, householdID); err == nil {
+		t.Fatal("membership of a deleted household should no longer resolve")
+	}
+
+	notifications, err := s.GetDeletedHouseholdNotifications(bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, notification := range notifications {
+		if notification.Action == models.NotificationActionHouseholdDeleted &&
+			notification.Household != nil && notification.Household.HouseholdID == householdID {
+			found = true
+			if notification.Actor.ID != alice {
+				t.Errorf("actor = %s, want %s", notification.Actor.ID, alice)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no HOUSEHOLD_DELETED notification for %s in %+v", householdID, notifications)
+	}
+}
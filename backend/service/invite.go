@@ -0,0 +1,195 @@
+package service
+
+import (
+	"chore-share/models"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInviteNotFound         = errors.New("invite not found")
+	ErrInviteExpired          = errors.New("invite has expired")
+	ErrInviteRevoked          = errors.New("invite has been revoked")
+	ErrInviteExhausted        = errors.New("invite has no uses left")
+	ErrInviteEmailMismatch    = errors.New("invite was issued for a different email")
+	ErrAlreadyHouseholdMember = errors.New("account is already a member of this household")
+)
+
+// Invite codes avoid characters that are easy to misread when shared aloud.
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+const inviteCodeLength = 8
+
+func newInviteCode() (string, error) {
+	code := make([]byte, inviteCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(inviteCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+func (s *dbService) CreateHouseholdInvite(invite *models.HouseholdInvite) error {
+	code, err := newInviteCode()
+	if err != nil {
+		return err
+	}
+	invite.Code = code
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invite).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: invite.HouseholdID,
+			ActorID:     &invite.CreatedByID,
+			Action:      models.AuditActionInviteCreated,
+			EntityType:  models.AuditEntityHouseholdInvite,
+			EntityID:    invite.ID,
+		}, nil, inviteSnapshot(*invite))
+	})
+	if err != nil {
+		return err
+	}
+	return s.db.Preload("CreatedBy").First(invite, invite.ID).Error
+}
+
+// GetActiveHouseholdInvites lists invites that can still be redeemed.
+func (s *dbService) GetActiveHouseholdInvites(householdID uuid.UUID) ([]models.HouseholdInvite, error) {
+	var invites []models.HouseholdInvite
+	err := s.db.Preload("CreatedBy").
+		Where("household_id = ? AND revoked_at IS NULL AND expires_at > ? AND use_count < max_uses", householdID, time.Now()).
+		Order("created_at DESC").
+		Find(&invites).Error
+	if err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+func (s *dbService) RevokeHouseholdInvite(inviteID uuid.UUID, actorID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var invite models.HouseholdInvite
+		if err := tx.Where("id = ? AND revoked_at IS NULL", inviteID).First(&invite).Error; err != nil {
+			// Revoking twice is a no-op
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return err
+		}
+
+		before := inviteSnapshot(invite)
+		now := time.Now()
+		invite.RevokedAt = &now
+		if err := tx.Model(&invite).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: invite.HouseholdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionInviteRevoked,
+			EntityType:  models.AuditEntityHouseholdInvite,
+			EntityID:    invite.ID,
+		}, before, inviteSnapshot(invite))
+	})
+}
+
+// AcceptHouseholdInvite redeems an invite code for the account and notifies
+// the existing members.
+func (s *dbService) AcceptHouseholdInvite(code string, accountID uuid.UUID) (models.Household, error) {
+	var invite models.HouseholdInvite
+	var existingMembers []uuid.UUID
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code = ?", strings.ToUpper(strings.TrimSpace(code))).
+			First(&invite).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrInviteNotFound
+			}
+			return err
+		}
+
+		switch {
+		case invite.RevokedAt != nil:
+			return ErrInviteRevoked
+		case !time.Now().Before(invite.ExpiresAt):
+			return ErrInviteExpired
+		case invite.UseCount >= invite.MaxUses:
+			return ErrInviteExhausted
+		}
+
+		if invite.Email != nil {
+			var account models.Account
+			if err := tx.First(&account, accountID).Error; err != nil {
+				return err
+			}
+			if !strings.EqualFold(account.Email, *invite.Email) {
+				return ErrInviteEmailMismatch
+			}
+		}
+
+		if err := tx.Model(&models.AccountHousehold{}).
+			Where("household_id = ?", invite.HouseholdID).
+			Pluck("account_id", &existingMembers).Error; err != nil {
+			return err
+		}
+		for _, member := range existingMembers {
+			if member == accountID {
+				return ErrAlreadyHouseholdMember
+			}
+		}
+
+		membership := models.AccountHousehold{
+			AccountID:   accountID,
+			HouseholdID: invite.HouseholdID,
+			Role:        models.HouseholdRoleMember,
+		}
+		if err := tx.Create(&membership).Error; err != nil {
+			return err
+		}
+
+		after := memberSnapshot(membership)
+		after["inviteId"] = invite.ID
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: invite.HouseholdID,
+			ActorID:     &accountID,
+			Action:      models.AuditActionMemberJoined,
+			EntityType:  models.AuditEntityHouseholdMember,
+			EntityID:    accountID,
+		}, nil, after); err != nil {
+			return err
+		}
+
+		return tx.Model(&invite).Update("use_count", gorm.Expr("use_count + 1")).Error
+	})
+	if err != nil {
+		return models.Household{}, err
+	}
+
+	var household models.Household
+	if err := s.db.First(&household, invite.HouseholdID).Error; err != nil {
+		return models.Household{}, err
+	}
+
+	notification := &models.Notification{
+		Action:    models.NotificationActionMemberJoined,
+		AccountID: accountID,
+	}
+	if err := s.CreateNotification(notification, existingMembers, invite.HouseholdID); err != nil {
+		return models.Household{}, err
+	}
+
+	return household, nil
+}
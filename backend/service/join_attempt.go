@@ -0,0 +1,120 @@
+package service
+
+import (
+	"chore-share/models"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Join attempt throttling: the first few failures are free, after which each
+// failure doubles the wait before the next attempt, up to a temporary lockout.
+const (
+	joinAttemptWindow      = 24 * time.Hour
+	freeJoinAttempts       = 3
+	joinBackoffBase        = 30 * time.Second
+	maxJoinBackoff         = 15 * time.Minute
+	joinLockoutThreshold   = 10
+	joinLockoutDuration    = time.Hour
+	joinAttemptHistorySize = 100
+)
+
+// JoinThrottledError is returned when an account or household has too many
+// recent failed join attempts.
+type JoinThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *JoinThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed join attempts, locked for %s", e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("too many failed join attempts, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// checkJoinThrottle enforces the backoff for both the account and the household.
+func checkJoinThrottle(tx *gorm.DB, householdID uuid.UUID, accountID uuid.UUID) error {
+	for _, column := range []string{"account_id", "household_id"} {
+		key := accountID
+		if column == "household_id" {
+			key = householdID
+		}
+
+		if err := checkJoinThrottleKey(tx, column, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkJoinThrottleKey(tx *gorm.DB, column string, key uuid.UUID) error {
+	now := time.Now()
+	since := now.Add(-joinAttemptWindow)
+
+	// Failures only count since the last success for this key
+	var lastSuccess models.JoinAttempt
+	err := tx.Where(column+" = ? AND succeeded = ? AND created_at > ?", key, true, since).
+		Order("created_at DESC").
+		First(&lastSuccess).Error
+	if err == nil {
+		since = lastSuccess.CreatedAt
+	} else if err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	var failures struct {
+		Count int
+		Last  *time.Time
+	}
+	if err := tx.Model(&models.JoinAttempt{}).
+		Select("COUNT(*) as count, MAX(created_at) as last").
+		Where(column+" = ? AND succeeded = ? AND created_at > ?", key, false, since).
+		Scan(&failures).Error; err != nil {
+		return err
+	}
+
+	if failures.Count < freeJoinAttempts || failures.Last == nil {
+		return nil
+	}
+
+	locked := failures.Count >= joinLockoutThreshold
+	wait := joinLockoutDuration
+	if !locked {
+		backoff := float64(joinBackoffBase) * math.Pow(2, float64(failures.Count-freeJoinAttempts))
+		wait = time.Duration(math.Min(backoff, float64(maxJoinBackoff)))
+	}
+
+	if retryAfter := failures.Last.Add(wait).Sub(now); retryAfter > 0 {
+		return &JoinThrottledError{RetryAfter: retryAfter, Locked: locked}
+	}
+	return nil
+}
+
+func (s *dbService) GetJoinAttempts(householdID uuid.UUID) ([]models.JoinAttemptResponse, error) {
+	var attempts []models.JoinAttempt
+	err := s.db.Preload("Account").
+		Where("household_id = ?", householdID).
+		Order("created_at DESC").
+		Limit(joinAttemptHistorySize).
+		Find(&attempts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]models.JoinAttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		response[i] = models.JoinAttemptResponse{
+			ID:          attempt.ID,
+			AccountID:   attempt.AccountID,
+			AccountName: attempt.Account.Name,
+			Succeeded:   attempt.Succeeded,
+			IPAddress:   attempt.IPAddress,
+			CreatedAt:   attempt.CreatedAt,
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,459 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrOwnerMustTransfer   = errors.New("the owner must transfer ownership before leaving")
+	ErrCannotRemoveOwner   = errors.New("the household owner cannot be removed")
+	ErrOutstandingBalances = errors.New("member has unsettled balances in this household")
+)
+
+var openAssignmentStatuses = []models.AssignmentStatus{
+	models.AssignmentStatusPending,
+	models.AssignmentStatusPlanned,
+	models.AssignmentStatusOverdue,
+}
+
+// RemoveHouseholdMember handles both a member leaving (actorID == memberID) and
+// an admin removing someone. The member's rotations are rebalanced and their
+// open assignments handed to the remaining members. Their pending swap requests
+// are cancelled and their upcoming time away dropped. Unsettled splits block the
+// departure unless settleBalances is set, in which case they are marked settled.
+func (s *dbService) RemoveHouseholdMember(householdID uuid.UUID, memberID uuid.UUID, actorID uuid.UUID, settleBalances bool) (models.MemberDepartureResponse, error) {
+	var response models.MemberDepartureResponse
+	var departure memberDeparture
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		response, departure, err = s.removeMember(tx, householdID, memberID, actorID, settleBalances)
+		return err
+	})
+	if err != nil {
+		return response, err
+	}
+	return response, s.announceDeparture(departure)
+}
+
+// memberDeparture is what a removal leaves to announce once it has committed.
+type memberDeparture struct {
+	householdID       uuid.UUID
+	memberID          uuid.UUID
+	actorID           uuid.UUID
+	remainingMembers  []uuid.UUID
+	reassignedPending []models.AccountChore
+	cancelledSwaps    []models.ChoreSwap
+}
+
+func (s *dbService) removeMember(tx *gorm.DB, householdID uuid.UUID, memberID uuid.UUID, actorID uuid.UUID, settleBalances bool) (models.MemberDepartureResponse, memberDeparture, error) {
+	var response models.MemberDepartureResponse
+	departure := memberDeparture{householdID: householdID, memberID: memberID, actorID: actorID}
+
+	var membership models.AccountHousehold
+	if err := tx.Where("account_id = ? AND household_id = ?", memberID, householdID).
+		First(&membership).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return response, departure, ErrNotHouseholdMember
+		}
+		return response, departure, err
+	}
+
+	if err := tx.Model(&models.AccountHousehold{}).
+		Where("household_id = ? AND account_id <> ?", householdID, memberID).
+		Pluck("account_id", &departure.remainingMembers).Error; err != nil {
+		return response, departure, err
+	}
+
+	if membership.Role == models.HouseholdRoleOwner {
+		if actorID != memberID {
+			return response, departure, ErrCannotRemoveOwner
+		}
+		if len(departure.remainingMembers) > 0 {
+			return response, departure, ErrOwnerMustTransfer
+		}
+	}
+
+	settled, err := s.resolveOutstandingSplits(tx, householdID, memberID, actorID, settleBalances)
+	if err != nil {
+		if err == ErrOutstandingBalances {
+			response.OutstandingSplits = settled
+		}
+		return response, departure, err
+	}
+	response.SettledSplits = len(settled)
+
+	// Before reassignment moves the assignments the requests are about
+	if departure.cancelledSwaps, err = cancelMemberSwaps(tx, householdID, memberID, actorID); err != nil {
+		return response, departure, err
+	}
+	if err := deleteUpcomingAvailability(tx, householdID, memberID, actorID); err != nil {
+		return response, departure, err
+	}
+
+	reassigned, err := s.reassignMemberChores(tx, householdID, memberID, departure.remainingMembers)
+	if err != nil {
+		return response, departure, err
+	}
+	response.ReassignedAssignments = len(reassigned)
+	for _, assignment := range reassigned {
+		if assignment.Status == models.AssignmentStatusPending || assignment.Status == models.AssignmentStatusOverdue {
+			departure.reassignedPending = append(departure.reassignedPending, assignment)
+		}
+
+		before := assignmentSnapshot(assignment)
+		before["accountId"] = memberID
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: householdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionAssignmentReassigned,
+			EntityType:  models.AuditEntityAccountChore,
+			EntityID:    assignment.ID,
+		}, before, assignmentSnapshot(assignment)); err != nil {
+			return response, departure, err
+		}
+	}
+
+	if err := tx.Delete(&membership).Error; err != nil {
+		return response, departure, err
+	}
+
+	action := models.AuditActionMemberLeft
+	if actorID != memberID {
+		action = models.AuditActionMemberRemoved
+	}
+	return response, departure, recordAudit(tx, models.AuditLog{
+		HouseholdID: householdID,
+		ActorID:     &actorID,
+		Action:      action,
+		EntityType:  models.AuditEntityHouseholdMember,
+		EntityID:    memberID,
+	}, memberSnapshot(membership), nil)
+}
+
+// announceDeparture tells the remaining members who left and which of the
+// departed member's assignments they picked up.
+func (s *dbService) announceDeparture(departure memberDeparture) error {
+	if len(departure.remainingMembers) == 0 {
+		return nil
+	}
+
+	action := models.NotificationActionMemberLeft
+	if departure.actorID != departure.memberID {
+		action = models.NotificationActionMemberRemoved
+	}
+	notification := &models.Notification{
+		Action:          action,
+		AccountID:       departure.actorID,
+		TargetAccountID: &departure.memberID,
+	}
+	if err := s.CreateNotification(notification, departure.remainingMembers, departure.householdID); err != nil {
+		return err
+	}
+
+	for _, assignment := range departure.reassignedPending {
+		notification := &models.Notification{
+			Action:         models.NotificationActionChoreAssigned,
+			AccountID:      assignment.AccountID,
+			ChoreID:        &assignment.ChoreID,
+			AccountChoreID: &assignment.ID,
+		}
+		if err := s.CreateNotification(notification, departure.remainingMembers, departure.householdID); err != nil {
+			return err
+		}
+	}
+
+	// Only the side still in the household needs to hear about it
+	for _, swap := range departure.cancelledSwaps {
+		other := swap.RecipientID
+		if other == departure.memberID {
+			other = swap.RequesterID
+		}
+		notification := &models.Notification{
+			Action:          models.NotificationActionSwapCancelled,
+			AccountID:       departure.actorID,
+			SwapID:          &swap.ID,
+			AccountChoreID:  &swap.OfferedAssignmentID,
+			TargetAccountID: &swap.RecipientID,
+		}
+		if err := s.CreateNotification(notification, []uuid.UUID{other}, departure.householdID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cancelMemberSwaps cancels the pending swap requests the member made or was
+// asked to answer in the household.
+func cancelMemberSwaps(tx *gorm.DB, householdID uuid.UUID, memberID uuid.UUID, actorID uuid.UUID) ([]models.ChoreSwap, error) {
+	var swaps []models.ChoreSwap
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("household_id = ? AND status = ?", householdID, models.SwapStatusPending).
+		Where("requester_id = ? OR recipient_id = ?", memberID, memberID).
+		Find(&swaps).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range swaps {
+		if err := cancelChoreSwap(tx, &swaps[i], actorID, now); err != nil {
+			return nil, err
+		}
+	}
+	return swaps, nil
+}
+
+// deleteUpcomingAvailability drops the member's time away that hasn't ended
+// yet, so it can't steer who covers what after they've gone. Past ranges stay
+// as history.
+func deleteUpcomingAvailability(tx *gorm.DB, householdID uuid.UUID, memberID uuid.UUID, actorID uuid.UUID) error {
+	loc, err := householdLocation(tx, householdID)
+	if err != nil {
+		return err
+	}
+
+	var ranges []models.MemberAvailability
+	if err := tx.Where("household_id = ? AND account_id = ? AND end_date >= ?",
+		householdID, memberID, time.Now().In(loc).Format(availabilityDateLayout)).
+		Find(&ranges).Error; err != nil {
+		return err
+	}
+
+	for _, availability := range ranges {
+		if err := tx.Delete(&availability).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: householdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionAvailabilityDeleted,
+			EntityType:  models.AuditEntityMemberAvailability,
+			EntityID:    availability.ID,
+		}, availabilitySnapshot(availability), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveOutstandingSplits returns the member's unsettled splits in the
+// household, settling them on the actor's behalf when settle is true and
+// failing otherwise.
+func (s *dbService) resolveOutstandingSplits(tx *gorm.DB, householdID uuid.UUID, memberID uuid.UUID, actorID uuid.UUID, settle bool) ([]models.TransactionSplitResponse, error) {
+	var splits []models.TransactionSplit
+	if err := tx.Preload("Transaction").Preload("OwedBy").Preload("OwedTo").
+		Joins("JOIN transactions ON transactions.id = transaction_splits.transaction_id").
+		Where("transactions.household_id = ? AND transaction_splits.is_settled = ?", householdID, false).
+		Where("transaction_splits.owed_by_id = ? OR transaction_splits.owed_to_id = ?", memberID, memberID).
+		Find(&splits).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]models.TransactionSplitResponse, len(splits))
+	for i, split := range splits {
+		response[i] = splitResponse(split)
+	}
+
+	if len(splits) == 0 {
+		return response, nil
+	}
+	if !settle {
+		return response, ErrOutstandingBalances
+	}
+
+	now := time.Now()
+	ids := make([]uuid.UUID, len(splits))
+	for i, split := range splits {
+		ids[i] = split.ID
+	}
+	if err := tx.Model(&models.TransactionSplit{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"is_settled": true, "settled_at": now}).Error; err != nil {
+		return nil, err
+	}
+
+	for _, split := range splits {
+		before := splitSnapshot(split)
+		split.IsSettled = true
+		split.SettledAt = &now
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: householdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionSplitSettled,
+			EntityType:  models.AuditEntityTransactionSplit,
+			EntityID:    split.ID,
+		}, before, splitSnapshot(split)); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// reassignMemberChores drops the member from every rotation in the household
+// and hands their open assignments to the remaining members.
+func (s *dbService) reassignMemberChores(tx *gorm.DB, householdID uuid.UUID, memberID uuid.UUID, remainingMembers []uuid.UUID) ([]models.AccountChore, error) {
+	var reassigned []models.AccountChore
+
+	var choreIDs []uuid.UUID
+	if err := tx.Model(&models.ChoreRotation{}).
+		Where("household_id = ? AND account_id = ?", householdID, memberID).
+		Distinct("chore_id").
+		Pluck("chore_id", &choreIDs).Error; err != nil {
+		return nil, err
+	}
+
+	for _, choreID := range choreIDs {
+		updated, err := s.removeFromRotation(tx, choreID, memberID)
+		if err != nil {
+			return nil, err
+		}
+		reassigned = append(reassigned, updated...)
+	}
+
+	// Whatever is left is not driven by a rotation, e.g. one-time chores
+	var assignments []models.AccountChore
+	if err := tx.Where("household_id = ? AND account_id = ? AND status IN ?", householdID, memberID, openAssignmentStatuses).
+		Order("due_date").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	// Assignments are kept as-is when the household is left empty
+	if len(remainingMembers) == 0 {
+		return reassigned, nil
+	}
+
+	for _, assignment := range assignments {
+		assignee, err := leastLoadedMember(tx, householdID, remainingMembers)
+		if err != nil {
+			return nil, err
+		}
+		assignment.AccountID = assignee
+		if err := tx.Save(&assignment).Error; err != nil {
+			return nil, err
+		}
+		reassigned = append(reassigned, assignment)
+	}
+
+	return reassigned, nil
+}
+
+// removeFromRotation deletes the member's rotation slot, renumbers the rest and
+// walks the chore's open assignments so the member's turns go to whoever follows
+// the previous assignee in the new rotation.
+func (s *dbService) removeFromRotation(tx *gorm.DB, choreID uuid.UUID, memberID uuid.UUID) ([]models.AccountChore, error) {
+	var rotations []models.ChoreRotation
+	if err := tx.Where("chore_id = ?", choreID).Order("rotation_order").Find(&rotations).Error; err != nil {
+		return nil, err
+	}
+
+	oldOrder := 0
+	var remaining []models.ChoreRotation
+	for _, rotation := range rotations {
+		if rotation.AccountID == memberID {
+			oldOrder = rotation.RotationOrder
+			if err := tx.Delete(&rotation).Error; err != nil {
+				return nil, err
+			}
+			continue
+		}
+		remaining = append(remaining, rotation)
+	}
+
+	newOrder := map[uuid.UUID]int{}
+	for i, rotation := range remaining {
+		newOrder[rotation.AccountID] = i
+		if rotation.RotationOrder != i {
+			if err := tx.Model(&rotation).Update("rotation_order", i).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var assignments []models.AccountChore
+	if err := tx.Where("chore_id = ? AND status IN ?", choreID, openAssignmentStatuses).
+		Order("due_date").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	// Nobody left to rotate through, the caller reassigns these like one-time chores
+	if len(remaining) == 0 {
+		return nil, nil
+	}
+
+	var reassigned []models.AccountChore
+	previous := (oldOrder - 1 + len(remaining)) % len(remaining)
+	for _, assignment := range assignments {
+		order, ok := newOrder[assignment.AccountID]
+		if !ok {
+			order = (previous + 1) % len(remaining)
+			assignment.AccountID = remaining[order].AccountID
+			reassigned = append(reassigned, assignment)
+		}
+		assignment.RotationOrder = order
+		if err := tx.Save(&assignment).Error; err != nil {
+			return nil, err
+		}
+		previous = order
+	}
+
+	return reassigned, nil
+}
+
+// leastLoadedMember picks the member with the fewest open assignments.
+func leastLoadedMember(tx *gorm.DB, householdID uuid.UUID, members []uuid.UUID) (uuid.UUID, error) {
+	var loads []struct {
+		AccountID uuid.UUID
+		Total     int
+	}
+	if err := tx.Model(&models.AccountChore{}).
+		Select("account_id, COUNT(*) as total").
+		Where("household_id = ? AND account_id IN ? AND status IN ?", householdID, members, openAssignmentStatuses).
+		Group("account_id").
+		Scan(&loads).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	counts := map[uuid.UUID]int{}
+	for _, load := range loads {
+		counts[load.AccountID] = load.Total
+	}
+
+	best := members[0]
+	for _, member := range members[1:] {
+		if counts[member] < counts[best] {
+			best = member
+		}
+	}
+	return best, nil
+}
+
+func splitResponse(split models.TransactionSplit) models.TransactionSplitResponse {
+	return models.TransactionSplitResponse{
+		ID:            split.ID,
+		TransactionID: split.TransactionID,
+		Description:   split.Transaction.Description,
+		SpentAt:       split.Transaction.SpentAt,
+		OwedByID:      split.OwedByID,
+		OwedToID:      split.OwedToID,
+		AmountInCents: split.AmountInCents,
+		IsSettled:     split.IsSettled,
+		SettledAt:     split.SettledAt,
+		OwedBy: models.TransactionMemberResponse{
+			ID:   split.OwedBy.ID,
+			Name: split.OwedBy.Name,
+		},
+		OwedTo: models.TransactionMemberResponse{
+			ID:   split.OwedTo.ID,
+			Name: split.OwedTo.Name,
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"chore-share/models"
+	"testing"
+	"time"
+)
+
+func TestDepartureCancelsSwapsAndUpcomingAvailability(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createTestAccount(t, s, "Alice")
+	bob := createTestAccount(t, s, "Bob")
+	carol := createTestAccount(t, s, "Carol")
+	householdID := createTestHousehold(t, s, alice, bob, carol)
+	_, assignment := createTestChore(t, s, models.Chore{
+		HouseholdID: householdID,
+		EndDate:     time.Now().Add(72 * time.Hour),
+	}, bob)
+
+	swap := models.ChoreSwap{
+		HouseholdID:         householdID,
+		RequesterID:         bob,
+		RecipientID:         carol,
+		OfferedAssignmentID: assignment.ID,
+		ExpiresAt:           time.Now().Add(time.Hour),
+	}
+	if err := s.CreateChoreSwap(&swap); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	past := models.MemberAvailability{HouseholdID: householdID, AccountID: bob, StartDate: now.AddDate(0, 0, -10), EndDate: now.AddDate(0, 0, -8)}
+	upcoming := models.MemberAvailability{HouseholdID: householdID, AccountID: bob, StartDate: now.AddDate(0, 0, 10), EndDate: now.AddDate(0, 0, 12)}
+	for _, availability := range []*models.MemberAvailability{&past, &upcoming} {
+		if _, err := s.CreateMemberAvailability(availability); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := s.RemoveHouseholdMember(householdID, bob, alice, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.db.First(&swap, swap.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if swap.Status != models.SwapStatusCancelled {
+		t.Errorf("swap is %s, want %s", swap.Status, models.SwapStatusCancelled)
+	}
+	var told int64
+	if err := s.db.Model(&models.AccountNotification{}).
+		Joins("JOIN notifications ON notifications.id = account_notifications.notification_id").
+		Where("notifications.swap_id = ? AND notifications.action = ? AND account_notifications.account_id = ?",
+			swap.ID, models.NotificationActionSwapCancelled, carol).
+		Count(&told).Error; err != nil {
+		t.Fatal(err)
+	}
+	if told != 1 {
+		t.Errorf("carol got %d SWAP_CANCELLED notifications, want 1", told)
+	}
+
+	var remaining []models.MemberAvailability
+	if err := s.db.Where("account_id = ? AND household_id = ?", bob, householdID).Find(&remaining).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != past.ID {
+		t.Errorf("bob has %d availability ranges left, want only the past one", len(remaining))
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidTokenScope = errors.New("invalid token scope")
+	ErrTokenNotFound     = errors.New("token not found")
+	ErrInvalidToken      = errors.New("invalid or expired token")
+)
+
+func (s *dbService) CreatePersonalAccessToken(token *models.PersonalAccessToken) error {
+	for _, scope := range token.ScopeList() {
+		if !validTokenScope(scope) {
+			return ErrInvalidTokenScope
+		}
+	}
+	if len(token.ScopeList()) == 0 {
+		return ErrInvalidTokenScope
+	}
+
+	if _, err := s.GetHouseholdMembership(token.AccountID, token.HouseholdID); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(token).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: token.HouseholdID,
+			ActorID:     &token.AccountID,
+			Action:      models.AuditActionAccessTokenCreated,
+			EntityType:  models.AuditEntityPersonalAccessToken,
+			EntityID:    token.ID,
+		}, nil, accessTokenSnapshot(*token))
+	})
+}
+
+func (s *dbService) GetPersonalAccessTokens(accountID uuid.UUID) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	err := s.db.Where("account_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", accountID, time.Now()).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (s *dbService) RevokePersonalAccessToken(accountID uuid.UUID, tokenID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var token models.PersonalAccessToken
+		if err := tx.Where("id = ? AND account_id = ? AND revoked_at IS NULL", tokenID, accountID).
+			First(&token).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrTokenNotFound
+			}
+			return err
+		}
+
+		before := accessTokenSnapshot(token)
+		now := time.Now()
+		token.RevokedAt = &now
+		if err := tx.Model(&token).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: token.HouseholdID,
+			ActorID:     &accountID,
+			Action:      models.AuditActionAccessTokenRevoked,
+			EntityType:  models.AuditEntityPersonalAccessToken,
+			EntityID:    token.ID,
+		}, before, accessTokenSnapshot(token))
+	})
+}
+
+// GetActivePersonalAccessToken resolves a token hash to a usable token and
+// records when it was last used.
+func (s *dbService) GetActivePersonalAccessToken(tokenHash string) (models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	now := time.Now()
+	err := s.db.Preload("Account").
+		Where("token_hash = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", tokenHash, now).
+		First(&token).Error
+	if err == gorm.ErrRecordNotFound {
+		return models.PersonalAccessToken{}, ErrInvalidToken
+	}
+	if err != nil {
+		return models.PersonalAccessToken{}, err
+	}
+
+	if err := s.db.Model(&token).Update("last_used_at", now).Error; err != nil {
+		return models.PersonalAccessToken{}, err
+	}
+	return token, nil
+}
+
+func validTokenScope(scope models.TokenScope) bool {
+	for _, s := range models.TokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"bytes"
+	"chore-share/models"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Upload limits, exported so handlers can enforce them before reading files.
+const (
+	MaxPhotosPerCompletion = 5
+	MaxPhotoBytes          = 10 << 20
+)
+
+const (
+	maxPhotoPixels   = 40_000_000 // Guards against small files that decode into huge images
+	thumbnailMaxSide = 320
+	photoURLExpiry   = 15 * time.Minute
+)
+
+var (
+	ErrTooManyPhotos = errors.New("too many photos")
+	ErrPhotoTooLarge = errors.New("photo is too large")
+	ErrInvalidPhoto  = errors.New("photos must be JPEG, PNG or GIF images")
+)
+
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// PhotoUpload is a photo as received from the client.
+type PhotoUpload struct {
+	Data []byte
+}
+
+type preparedPhoto struct {
+	id          uuid.UUID
+	contentType string
+	data        []byte
+	thumbnail   []byte
+	width       int
+	height      int
+}
+
+// preparePhotos checks the uploads and renders their thumbnails, so nothing
+// slow happens while the assignment is locked.
+func preparePhotos(uploads []PhotoUpload) ([]preparedPhoto, error) {
+	if len(uploads) > MaxPhotosPerCompletion {
+		return nil, ErrTooManyPhotos
+	}
+
+	prepared := make([]preparedPhoto, len(uploads))
+	for i, upload := range uploads {
+		if len(upload.Data) > MaxPhotoBytes {
+			return nil, ErrPhotoTooLarge
+		}
+		contentType := http.DetectContentType(upload.Data)
+		if _, ok := photoExtensions[contentType]; !ok {
+			return nil, ErrInvalidPhoto
+		}
+
+		config, _, err := image.DecodeConfig(bytes.NewReader(upload.Data))
+		if err != nil {
+			return nil, ErrInvalidPhoto
+		}
+		if config.Width*config.Height > maxPhotoPixels {
+			return nil, ErrPhotoTooLarge
+		}
+		img, _, err := image.Decode(bytes.NewReader(upload.Data))
+		if err != nil {
+			return nil, ErrInvalidPhoto
+		}
+
+		var thumb bytes.Buffer
+		if err := jpeg.Encode(&thumb, thumbnail(img, thumbnailMaxSide), &jpeg.Options{Quality: 80}); err != nil {
+			return nil, err
+		}
+
+		prepared[i] = preparedPhoto{
+			id:          uuid.New(),
+			contentType: contentType,
+			data:        upload.Data,
+			thumbnail:   thumb.Bytes(),
+			width:       config.Width,
+			height:      config.Height,
+		}
+	}
+	return prepared, nil
+}
+
+// thumbnail scales img to fit within maxSide on both edges by averaging the
+// source pixels behind each target pixel. Transparency is flattened onto white.
+func thumbnail(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	thumbWidth, thumbHeight := width, height
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			thumbWidth, thumbHeight = maxSide, max(1, height*maxSide/width)
+		} else {
+			thumbWidth, thumbHeight = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Over)
+
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		y0 := y * height / thumbHeight
+		y1 := max((y+1)*height/thumbHeight, y0+1)
+		for x := 0; x < thumbWidth; x++ {
+			x0 := x * width / thumbWidth
+			x1 := max((x+1)*width/thumbWidth, x0+1)
+
+			var r, g, b, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 255})
+		}
+	}
+	return dst
+}
+
+// storePhotos uploads the photos and records them against the assignment and
+// the completion they prove. The returned keys are what made it into storage,
+// so callers can clean up when the transaction fails.
+func (s *dbService) storePhotos(tx *gorm.DB, assignment models.AccountChore, completionID uuid.UUID, uploaderID uuid.UUID, photos []preparedPhoto) ([]string, error) {
+	var stored []string
+	for _, photo := range photos {
+		prefix := fmt.Sprintf("households/%s/assignments/%s/%s", assignment.HouseholdID, assignment.ID, photo.id)
+		key := prefix + photoExtensions[photo.contentType]
+		thumbnailKey := prefix + "_thumb.jpg"
+
+		if err := s.photos.Put(context.Background(), key, photo.contentType, photo.data); err != nil {
+			return stored, err
+		}
+		stored = append(stored, key)
+		if err := s.photos.Put(context.Background(), thumbnailKey, "image/jpeg", photo.thumbnail); err != nil {
+			return stored, err
+		}
+		stored = append(stored, thumbnailKey)
+
+		if err := tx.Create(&models.ChorePhoto{
+			ID:             photo.id,
+			AccountChoreID: assignment.ID,
+			CompletionID:   &completionID,
+			HouseholdID:    assignment.HouseholdID,
+			UploadedByID:   uploaderID,
+			Key:            key,
+			ThumbnailKey:   thumbnailKey,
+			ContentType:    photo.contentType,
+			Size:           len(photo.data),
+			Width:          photo.width,
+			Height:         photo.height,
+		}).Error; err != nil {
+			return stored, err
+		}
+	}
+	return stored, nil
+}
+
+// deleteBlobs removes uploads whose database rows never made it. Failures
+// only leave orphaned files behind, so they are logged rather than returned.
+func (s *dbService) deleteBlobs(keys []string) {
+	for _, key := range keys {
+		if err := s.photos.Delete(context.Background(), key); err != nil {
+			log.Printf("Error deleting orphaned photo %s: %v", key, err)
+		}
+	}
+}
+
+func (s *dbService) GetAssignmentPhotos(accountChoreID uuid.UUID) ([]models.ChorePhotoResponse, error) {
+	photos, err := s.assignmentPhotos([]uuid.UUID{accountChoreID})
+	if err != nil {
+		return nil, err
+	}
+	if photos[accountChoreID] == nil {
+		return []models.ChorePhotoResponse{}, nil
+	}
+	return photos[accountChoreID], nil
+}
+
+// assignmentPhotos loads the photos of several assignments at once, keyed by
+// assignment, with freshly signed URLs.
+func (s *dbService) assignmentPhotos(accountChoreIDs []uuid.UUID) (map[uuid.UUID][]models.ChorePhotoResponse, error) {
+	photos := map[uuid.UUID][]models.ChorePhotoResponse{}
+	if len(accountChoreIDs) == 0 {
+		return photos, nil
+	}
+
+	var rows []models.ChorePhoto
+	if err := s.db.Where("account_chore_id IN ?", accountChoreIDs).
+		Order("created_at, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(photoURLExpiry)
+	for _, photo := range rows {
+		url, err := s.photos.SignedURL(photo.Key, photoURLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		thumbnailURL, err := s.photos.SignedURL(photo.ThumbnailKey, photoURLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		photos[photo.AccountChoreID] = append(photos[photo.AccountChoreID], models.ChorePhotoResponse{
+			ID:           photo.ID,
+			URL:          url,
+			ThumbnailURL: thumbnailURL,
+			ContentType:  photo.ContentType,
+			Width:        photo.Width,
+			Height:       photo.Height,
+			UploadedByID: photo.UploadedByID,
+			CreatedAt:    photo.CreatedAt,
+			ExpiresAt:    expiresAt,
+		})
+	}
+	return photos, nil
+}
+
+// completionAction reports whether a notification announces a completion, and
+// so carries the photos submitted with it.
+func completionAction(action string) bool {
+	return action == models.NotificationActionChoreCompleted || action == models.NotificationActionChoreAwaitingReview
+}
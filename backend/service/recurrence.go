@@ -0,0 +1,184 @@
+package service
+
+import (
+	"chore-share/models"
+	"chore-share/recurrence"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultHorizonDays is how far ahead recurring assignments are planned unless
+// the service is configured otherwise.
+const DefaultHorizonDays = 7
+
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// prepareRecurrence validates a new recurring chore's rule and fills in the
+// anchor and frequency type derived from it.
+func prepareRecurrence(chore *models.Chore, schedule []models.ChoreSchedule) error {
+	if chore.Recurrence == "" {
+		chore.Recurrence = legacyRecurrence(chore, schedule).String()
+	}
+	rule, err := recurrence.Parse(chore.Recurrence)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	chore.Recurrence = rule.String()
+
+	if chore.RecurrenceStart == nil {
+		now := time.Now()
+		chore.RecurrenceStart = &now
+	}
+
+	frequencyType := models.FrequencyType(rule.Frequency)
+	chore.FrequencyType = &frequencyType
+	return nil
+}
+
+// choreRecurrence returns the chore's rule and the date it is anchored at, in
+// the household's timezone so every date the rule produces is a local day.
+// Chores created before rules existed are read from their weekly schedule.
+func choreRecurrence(tx *gorm.DB, chore *models.Chore) (*recurrence.Rule, time.Time, error) {
+	loc, err := householdLocation(tx, chore.HouseholdID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	start := chore.CreatedAt.In(loc)
+	if chore.RecurrenceStart != nil {
+		start = chore.RecurrenceStart.In(loc)
+	}
+
+	if chore.Recurrence != "" {
+		rule, err := recurrence.Parse(chore.Recurrence)
+		return rule, start, err
+	}
+
+	var schedules []models.ChoreSchedule
+	if err := tx.Where("chore_id = ?", chore.ID).Find(&schedules).Error; err != nil {
+		return nil, start, err
+	}
+	return legacyRecurrence(chore, schedules), start, nil
+}
+
+// legacyRecurrence builds a rule from the frequency type and the days of the
+// week (1-7 for Monday-Sunday) recurring chores used to be created with.
+func legacyRecurrence(chore *models.Chore, schedules []models.ChoreSchedule) *recurrence.Rule {
+	rule := &recurrence.Rule{Frequency: recurrence.Weekly, Interval: 1, WeekStart: time.Monday}
+	if chore.FrequencyType != nil && *chore.FrequencyType == models.FrequencyTypeDaily && len(schedules) == 0 {
+		rule.Frequency = recurrence.Daily
+	}
+	for _, sched := range schedules {
+		rule.ByDay = append(rule.ByDay, recurrence.WeekdayNum{Weekday: time.Weekday(sched.DayOfWeek % 7)})
+	}
+	return rule
+}
+
+// dueDateOn returns the end of the given day in its own location, which is when
+// assignments are due. Building it with time.Date keeps it at 23:59:59 local
+// time on days where DST starts or ends.
+func dueDateOn(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location())
+}
+
+// extendAssignments plans the chore's occurrences from today, or the day after
+// its latest assignment, up to and including until, continuing the rotation. When that still leaves nothing open the
+// next occurrence is planned regardless, so sparse rules such as monthly chores
+// always have an upcoming assignment. The IDs of new assignments are returned.
+// actorID is nil when the scheduler plans ahead on its own. It holds the
+// horizon job's lock for the rest of tx so a request and the scheduler can't
+// both plan the same occurrence.
+func (s *dbService) extendAssignments(tx *gorm.DB, chore *models.Chore, until time.Time, actorID *uuid.UUID) ([]uuid.UUID, error) {
+	if err := jobLock(tx, horizonJobLockKey); err != nil {
+		return nil, err
+	}
+
+	var rotations []models.ChoreRotation
+	if err := tx.Where("chore_id = ?", chore.ID).Order("rotation_order").Find(&rotations).Error; err != nil {
+		return nil, err
+	}
+	if len(rotations) == 0 {
+		return nil, nil
+	}
+
+	rule, start, err := choreRecurrence(tx, chore)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest models.AccountChore
+	from := start
+	order := -1
+	err = tx.Where("chore_id = ? AND status <> ?", chore.ID, models.AssignmentStatusCancelled).
+		Order("due_date DESC").
+		First(&latest).Error
+	if err == nil {
+		from = latest.DueDate.In(start.Location()).AddDate(0, 0, 1)
+		order = latest.RotationOrder
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	// Occurrences that have already passed are skipped rather than back-filled
+	if today := time.Now(); from.Before(today) {
+		from = today
+	}
+
+	days := rule.Between(start, from, until.AddDate(0, 0, 1))
+	if len(days) == 0 {
+		var open int64
+		if err := tx.Model(&models.AccountChore{}).
+			Where("chore_id = ? AND status IN ?", chore.ID, openAssignmentStatuses).
+			Count(&open).Error; err != nil {
+			return nil, err
+		}
+		if open == 0 {
+			if next, ok := rule.Next(start, from); ok {
+				days = append(days, next)
+			}
+		}
+	}
+
+	calendar, err := loadAvailability(tx, chore.HouseholdID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := strategyFor(chore)
+	var created []uuid.UUID
+	for _, day := range days {
+		if order, err = strategy.NextSlot(tx, chore, rotations, order, day); err != nil {
+			return nil, err
+		}
+		// The slot keeps its turn even when someone else covers it
+		accountID, coveredFor := calendar.assignee(rotations, order, day)
+		assignment := models.AccountChore{
+			ChoreID:       chore.ID,
+			AccountID:     accountID,
+			CoveredForID:  coveredFor,
+			HouseholdID:   chore.HouseholdID,
+			DueDate:       dueDateOn(day),
+			Status:        models.AssignmentStatusPlanned,
+			RotationOrder: order,
+			Points:        chore.Points,
+		}
+		if err := tx.Create(&assignment).Error; err != nil {
+			return nil, err
+		}
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: chore.HouseholdID,
+			ActorID:     actorID,
+			Action:      models.AuditActionAssignmentCreated,
+			EntityType:  models.AuditEntityAccountChore,
+			EntityID:    assignment.ID,
+		}, nil, assignmentSnapshot(assignment)); err != nil {
+			return nil, err
+		}
+		created = append(created, assignment.ID)
+	}
+	return created, nil
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAssignmentNotFound      = errors.New("assignment not found")
+	ErrInvalidReviewStatus     = errors.New("review status must be APPROVED or REJECTED")
+	ErrRejectionReasonRequired = errors.New("a rejection needs a reason")
+	ErrSelfReview              = errors.New("chores must be reviewed by another member")
+	ErrAssignmentNotReviewable = errors.New("only completed chores can be reviewed")
+	ErrAlreadyReviewed         = errors.New("you have already reviewed this completion")
+	ErrInvalidRating           = errors.New("rating must be between 1 and 5")
+)
+
+const (
+	minReviewRating = 1
+	maxReviewRating = 5
+)
+
+func validateReview(review models.ChoreReview) error {
+	if review.Rating != nil && (*review.Rating < minReviewRating || *review.Rating > maxReviewRating) {
+		return ErrInvalidRating
+	}
+	switch review.ReviewerStatus {
+	case models.ReviewStatusApproved:
+		return nil
+	case models.ReviewStatusRejected:
+		if strings.TrimSpace(review.Review) == "" {
+			return ErrRejectionReasonRequired
+		}
+		return nil
+	default:
+		return ErrInvalidReviewStatus
+	}
+}
+
+// settleReview finalizes an assignment that was waiting for review. Approval
+// completes it, so its points start counting; rejection reopens it with the
+// review as the reason and takes back the rotation's advance, so the chore
+// doesn't end up with two open turns. It returns the notification action for
+// the outcome.
+func settleReview(tx *gorm.DB, assignment *models.AccountChore, review models.ChoreReview, now time.Time) (string, error) {
+	before := assignmentSnapshot(*assignment)
+
+	if review.ReviewerStatus != models.ReviewStatusApproved {
+		completion, err := currentCompletion(tx, *assignment)
+		if err != nil {
+			return "", err
+		}
+		if completion != nil {
+			if err := revertCompletion(tx, *completion, now); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	action := models.AuditActionChoreApproved
+	notificationAction := models.NotificationActionChoreApproved
+	if review.ReviewerStatus == models.ReviewStatusApproved {
+		assignment.Status = models.AssignmentStatusCompleted
+		assignment.RejectionReason = ""
+	} else {
+		action = models.AuditActionChoreRejected
+		notificationAction = models.NotificationActionChoreRejected
+		assignment.Status = models.AssignmentStatusPending
+		if assignment.DueDate.Before(now) {
+			assignment.Status = models.AssignmentStatusOverdue
+		}
+		assignment.CompletedAt = nil
+		assignment.RejectionReason = strings.TrimSpace(review.Review)
+	}
+
+	if err := tx.Model(assignment).Updates(map[string]interface{}{
+		"status":           assignment.Status,
+		"completed_at":     assignment.CompletedAt,
+		"rejection_reason": assignment.RejectionReason,
+	}).Error; err != nil {
+		return "", err
+	}
+
+	after := assignmentSnapshot(*assignment)
+	after["reviewId"] = review.ID
+	if err := recordAudit(tx, models.AuditLog{
+		HouseholdID: assignment.HouseholdID,
+		ActorID:     &review.ReviewerID,
+		Action:      action,
+		EntityType:  models.AuditEntityAccountChore,
+		EntityID:    assignment.ID,
+	}, before, after); err != nil {
+		return "", err
+	}
+	return notificationAction, nil
+}
+
+// currentCompletion returns the completion the assignment's status reflects,
+// or nil when there is none on record.
+func currentCompletion(tx *gorm.DB, assignment models.AccountChore) (*models.ChoreCompletion, error) {
+	if assignment.CompletedAt == nil {
+		return nil, nil
+	}
+	var completions []models.ChoreCompletion
+	if err := tx.Where("account_chore_id = ? AND completed_at = ? AND undone_at IS NULL", assignment.ID, *assignment.CompletedAt).
+		Limit(1).
+		Find(&completions).Error; err != nil {
+		return nil, err
+	}
+	if len(completions) == 0 {
+		return nil, nil
+	}
+	return &completions[0], nil
+}
+
+// alreadyReviewed reports whether the reviewer has reviewed the assignment's
+// current completion. A rejected chore that is completed again can be reviewed
+// again by the same member.
+func alreadyReviewed(tx *gorm.DB, assignment models.AccountChore, reviewerID uuid.UUID) (bool, error) {
+	query := tx.Model(&models.ChoreReview{}).
+		Where("account_chore_id = ? AND reviewer_id = ?", assignment.ID, reviewerID)
+	if assignment.CompletedAt != nil {
+		query = query.Where("created_at >= ?", *assignment.CompletedAt)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *dbService) GetAssignmentReviews(accountChoreID uuid.UUID) (models.ReviewListResponse, error) {
+	return s.reviewList(s.db.Where("chore_reviews.account_chore_id = ?", accountChoreID))
+}
+
+// GetChoreReviews lists the reviews of every occurrence of a chore.
+func (s *dbService) GetChoreReviews(choreID uuid.UUID) (models.ReviewListResponse, error) {
+	return s.reviewList(s.db.
+		Joins("JOIN account_chores ON account_chores.id = chore_reviews.account_chore_id").
+		Where("account_chores.chore_id = ?", choreID))
+}
+
+// GetMemberReviews lists the reviews a member received in a household.
+func (s *dbService) GetMemberReviews(householdID uuid.UUID, memberID uuid.UUID) (models.ReviewListResponse, error) {
+	return s.reviewList(s.db.
+		Joins("JOIN account_chores ON account_chores.id = chore_reviews.account_chore_id").
+		Where("chore_reviews.household_id = ? AND account_chores.account_id = ?", householdID, memberID))
+}
+
+func (s *dbService) reviewList(query *gorm.DB) (models.ReviewListResponse, error) {
+	var reviews []models.ChoreReview
+	if err := preloadReviews(query).
+		Order("chore_reviews.created_at DESC").
+		Find(&reviews).Error; err != nil {
+		return models.ReviewListResponse{}, err
+	}
+
+	assignmentIDs := make([]uuid.UUID, len(reviews))
+	for i, review := range reviews {
+		assignmentIDs[i] = review.AccountChoreID
+	}
+	photos, err := s.assignmentPhotos(assignmentIDs)
+	if err != nil {
+		return models.ReviewListResponse{}, err
+	}
+
+	response := models.ReviewListResponse{
+		Reviews: make([]models.ChoreReviewResponse, len(reviews)),
+		Summary: summarizeReviews(reviews),
+	}
+	for i, review := range reviews {
+		response.Reviews[i] = reviewResponse(review, photos[review.AccountChoreID])
+	}
+	return response, nil
+}
+
+func preloadReviews(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Reviewer").
+		Preload("AccountChore.Chore").
+		Preload("AccountChore.Account").
+		Preload("MissedItems", func(db *gorm.DB) *gorm.DB { return db.Order("position") })
+}
+
+// summarizeReviews counts verdicts and averages ratings. Reviews written before
+// verdicts were validated count towards the total only.
+func summarizeReviews(reviews []models.ChoreReview) models.ReviewSummary {
+	summary := models.ReviewSummary{Total: len(reviews)}
+	ratingSum := 0
+	for _, review := range reviews {
+		switch review.ReviewerStatus {
+		case models.ReviewStatusApproved:
+			summary.Approved++
+		case models.ReviewStatusRejected:
+			summary.Rejected++
+		}
+		if review.Rating != nil {
+			summary.Rated++
+			ratingSum += *review.Rating
+		}
+	}
+
+	if verdicts := summary.Approved + summary.Rejected; verdicts > 0 {
+		rate := float64(summary.Approved) / float64(verdicts)
+		summary.ApprovalRate = &rate
+	}
+	if summary.Rated > 0 {
+		average := float64(ratingSum) / float64(summary.Rated)
+		summary.AverageRating = &average
+	}
+	return summary
+}
+
+func reviewResponse(review models.ChoreReview, photos []models.ChorePhotoResponse) models.ChoreReviewResponse {
+	response := models.ChoreReviewResponse{
+		ID:             review.ID,
+		AccountChoreID: review.AccountChoreID,
+		ChoreID:        review.AccountChore.ChoreID,
+		ChoreTitle:     review.AccountChore.Chore.Title,
+		RevieweeID:     review.AccountChore.AccountID,
+		RevieweeName:   review.AccountChore.Account.Name,
+		ReviewerID:     review.ReviewerID,
+		ReviewerName:   review.Reviewer.Name,
+		ReviewerStatus: review.ReviewerStatus,
+		Rating:         review.Rating,
+		ReviewComment:  review.Review,
+		CreatedAt:      review.CreatedAt,
+		Photos:         photos,
+	}
+	for _, item := range review.MissedItems {
+		response.MissedItems = append(response.MissedItems, checklistItemResponse(item))
+	}
+	return response
+}
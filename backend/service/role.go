@@ -0,0 +1,86 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidRole           = errors.New("invalid household role")
+	ErrCannotChangeOwnerRole = errors.New("the owner's role can only change through an ownership transfer")
+)
+
+// UpdateMemberRole promotes or demotes a member between ADMIN and MEMBER.
+func (s *dbService) UpdateMemberRole(householdID uuid.UUID, accountID uuid.UUID, role models.HouseholdRole, actorID uuid.UUID) error {
+	if role != models.HouseholdRoleAdmin && role != models.HouseholdRoleMember {
+		return ErrInvalidRole
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var membership models.AccountHousehold
+		if err := tx.Where("account_id = ? AND household_id = ?", accountID, householdID).
+			First(&membership).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrNotHouseholdMember
+			}
+			return err
+		}
+
+		if membership.Role == models.HouseholdRoleOwner {
+			return ErrCannotChangeOwnerRole
+		}
+
+		before := memberSnapshot(membership)
+		if err := tx.Model(&membership).Update("role", role).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: householdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionMemberRoleChanged,
+			EntityType:  models.AuditEntityHouseholdMember,
+			EntityID:    accountID,
+		}, before, memberSnapshot(membership))
+	})
+}
+
+// TransferHouseholdOwnership makes another member the owner and demotes the
+// previous owner to admin.
+func (s *dbService) TransferHouseholdOwnership(householdID uuid.UUID, fromAccountID uuid.UUID, toAccountID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return transferOwnership(tx, householdID, fromAccountID, toAccountID)
+	})
+}
+
+func transferOwnership(tx *gorm.DB, householdID uuid.UUID, fromAccountID uuid.UUID, toAccountID uuid.UUID) error {
+	var target models.AccountHousehold
+	if err := tx.Where("account_id = ? AND household_id = ?", toAccountID, householdID).
+		First(&target).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrNotHouseholdMember
+		}
+		return err
+	}
+
+	if err := tx.Model(&models.AccountHousehold{}).
+		Where("account_id = ? AND household_id = ? AND role = ?", fromAccountID, householdID, models.HouseholdRoleOwner).
+		Update("role", models.HouseholdRoleAdmin).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&target).Update("role", models.HouseholdRoleOwner).Error; err != nil {
+		return err
+	}
+
+	return recordAudit(tx, models.AuditLog{
+		HouseholdID: householdID,
+		ActorID:     &fromAccountID,
+		Action:      models.AuditActionOwnershipTransferred,
+		EntityType:  models.AuditEntityHousehold,
+		EntityID:    householdID,
+	}, map[string]interface{}{"ownerId": fromAccountID}, map[string]interface{}{"ownerId": toAccountID})
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"chore-share/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Advisory lock keys for the scheduled jobs. Each job runs inside a transaction
+// holding its lock, so when several instances tick at once only one does work.
+const (
+	overdueJobLockKey = 720001
+	horizonJobLockKey = 720002
+)
+
+// MarkOverdueAssignments flips PENDING assignments whose due date has passed to
+// OVERDUE. PLANNED ones were never handed to their assignee, so they can't be
+// late. It also notifies the household and promotes the next assignment of each
+// affected recurring chore so the rotation keeps moving. It returns how many
+// assignments became overdue.
+func (s *dbService) MarkOverdueAssignments(now time.Time) (int, error) {
+	var overdue []models.AccountChore
+	var promoted []models.AccountChore
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if locked, err := tryJobLock(tx, overdueJobLockKey); err != nil || !locked {
+			return err
+		}
+
+		if err := tx.Raw(`SELECT account_chores.* FROM account_chores
+			JOIN households ON households.id = account_chores.household_id AND households.deleted_at IS NULL
+			WHERE account_chores.status = ? AND account_chores.due_date < ?
+			ORDER BY account_chores.due_date
+			FOR UPDATE OF account_chores SKIP LOCKED`,
+			models.AssignmentStatusPending, now).
+			Scan(&overdue).Error; err != nil {
+			return err
+		}
+		if len(overdue) == 0 {
+			return nil
+		}
+
+		choreIDs := map[uuid.UUID]bool{}
+		for i := range overdue {
+			before := assignmentSnapshot(overdue[i])
+			overdue[i].Status = models.AssignmentStatusOverdue
+			if err := tx.Model(&overdue[i]).Update("status", models.AssignmentStatusOverdue).Error; err != nil {
+				return err
+			}
+			if err := recordAudit(tx, models.AuditLog{
+				HouseholdID: overdue[i].HouseholdID,
+				Action:      models.AuditActionAssignmentOverdue,
+				EntityType:  models.AuditEntityAccountChore,
+				EntityID:    overdue[i].ID,
+			}, before, assignmentSnapshot(overdue[i])); err != nil {
+				return err
+			}
+			choreIDs[overdue[i].ChoreID] = true
+		}
+
+		for choreID := range choreIDs {
+			next, err := promoteNextAssignment(tx, choreID, now)
+			if err != nil {
+				return err
+			}
+			if next != nil {
+				promoted = append(promoted, *next)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, assignment := range overdue {
+		if err := s.notifyHousehold(models.NotificationActionChoreOverdue, assignment); err != nil {
+			return len(overdue), err
+		}
+	}
+	for _, assignment := range promoted {
+		if err := s.notifyHousehold(models.NotificationActionChoreAssigned, assignment); err != nil {
+			return len(overdue), err
+		}
+	}
+	return len(overdue), nil
+}
+
+// ExtendRecurringAssignments plans every active recurring chore's assignments
+// up to horizonDays ahead and makes sure each one has a PENDING assignment. It
+// returns how many assignments were created.
+func (s *dbService) ExtendRecurringAssignments(now time.Time, horizonDays int) (int, error) {
+	created := 0
+	var promoted []models.AccountChore
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if locked, err := tryJobLock(tx, horizonJobLockKey); err != nil || !locked {
+			return err
+		}
+
+		var chores []models.Chore
+		if err := tx.Joins("JOIN households ON households.id = chores.household_id AND households.deleted_at IS NULL").
+			Where("chores.type = ? AND chores.archived_at IS NULL", models.ChoreTypeRecurring).
+			Find(&chores).Error; err != nil {
+			return err
+		}
+
+		until := now.AddDate(0, 0, horizonDays)
+		for i := range chores {
+			ids, err := s.extendAssignments(tx, &chores[i], until, nil)
+			if err != nil {
+				return err
+			}
+			created += len(ids)
+
+			next, err := promoteNextAssignment(tx, chores[i].ID, now)
+			if err != nil {
+				return err
+			}
+			if next != nil {
+				promoted = append(promoted, *next)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, assignment := range promoted {
+		if err := s.notifyHousehold(models.NotificationActionChoreAssigned, assignment); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// jobLock takes a transaction-scoped advisory lock, waiting for whoever holds
+// it. Taking a lock the transaction already holds returns straight away.
+func jobLock(tx *gorm.DB, key int64) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", key).Error
+}
+
+// tryJobLock takes a transaction-scoped advisory lock without waiting.
+func tryJobLock(tx *gorm.DB, key int64) (bool, error) {
+	var locked bool
+	err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error
+	return locked, err
+}
+
+// promoteNextAssignment makes the chore's earliest upcoming PLANNED assignment
+// PENDING, unless the chore already has one. It returns the promoted assignment.
+func promoteNextAssignment(tx *gorm.DB, choreID uuid.UUID, now time.Time) (*models.AccountChore, error) {
+	var pending int64
+	if err := tx.Model(&models.AccountChore{}).
+		Where("chore_id = ? AND status = ?", choreID, models.AssignmentStatusPending).
+		Count(&pending).Error; err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		return nil, nil
+	}
+
+	var next models.AccountChore
+	err := tx.Where("chore_id = ? AND status = ? AND due_date >= ?", choreID, models.AssignmentStatusPlanned, now).
+		Order("due_date").
+		First(&next).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	next.Status = models.AssignmentStatusPending
+	if err := tx.Model(&next).Update("status", models.AssignmentStatusPending).Error; err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// notifyHousehold sends an assignment notification to every household member.
+func (s *dbService) notifyHousehold(action string, assignment models.AccountChore) error {
+	var householdMembers []uuid.UUID
+	if err := s.db.Model(&models.AccountHousehold{}).
+		Where("household_id = ?", assignment.HouseholdID).
+		Pluck("account_id", &householdMembers).Error; err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		Action:         action,
+		AccountID:      assignment.AccountID,
+		ChoreID:        &assignment.ChoreID,
+		AccountChoreID: &assignment.ID,
+	}
+	return s.CreateNotification(notification, householdMembers, assignment.HouseholdID)
+}
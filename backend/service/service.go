@@ -2,6 +2,7 @@ package service
 
 import (
 	"chore-share/models"
+	"chore-share/storage"
 	"errors"
 	"time"
 
@@ -9,37 +10,101 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DBService interface {
 	CreateAccount(account *models.Account) (models.AccountResponse, error)
-	CreateChore(chore *models.Chore, assignees []uuid.UUID, schedule []models.ChoreSchedule) error
+	CreateChore(chore *models.Chore, assignees []uuid.UUID, schedule []models.ChoreSchedule, actorID uuid.UUID) error
 	GetAccount(accountId uuid.UUID) (models.AccountResponse, error)
 	GetAccountByGoogleId(googleId string) (models.AccountResponse, error)
-	CreateHousehold(household *models.Household) error
-	JoinHousehold(householdId uuid.UUID, accountId uuid.UUID, password string) error
+	CreateHousehold(household *models.Household, ownerID uuid.UUID) error
+	JoinHousehold(householdId uuid.UUID, accountId uuid.UUID, password string, clientIP string) error
 	GetAccountHouseholds(accountId uuid.UUID) ([]models.HouseholdResponse, error)
 	GetAccountChores(accountId uuid.UUID, householdId uuid.UUID) ([]models.AccountChoreResponse, error)
 	GetHouseholdChores(householdId uuid.UUID) ([]models.AccountChoreResponse, error)
 	GetHouseholdLeaderboard(householdId uuid.UUID) ([]models.LeaderboardEntryResponse, error)
 	GetHouseholdMembers(householdId uuid.UUID) ([]models.HouseholdMemberResponse, error)
-	CompleteChore(accountChoreId uuid.UUID) error
+	CompleteChore(accountChoreId uuid.UUID, actorID uuid.UUID, photos []PhotoUpload) error
 	CreateTransaction(transaction *models.Transaction) error
 	GetTransactionSummary(accountID, householdID uuid.UUID, month time.Time) (models.TransactionSummary, error)
-	SettleTransactionSplit(splitID uuid.UUID) error
+	SettleTransactionSplit(splitID uuid.UUID, actorID uuid.UUID) error
 	CreateNotification(notification *models.Notification, recipientIDs []uuid.UUID, householdID uuid.UUID) error
 	GetAccountNotifications(accountID uuid.UUID, householdID uuid.UUID) ([]models.NotificationResponse, error)
-	MarkNotificationAsSeen(accountID uuid.UUID, notificationID uuid.UUID) error
+	GetDeletedHouseholdNotifications(accountID uuid.UUID) ([]models.NotificationResponse, error)
+	MarkNotificationAsSeen(accountID uuid.UUID, householdID uuid.UUID, notificationID uuid.UUID) error
 	CreateChoreReview(review *models.ChoreReview) error
 	GetChoreReview(reviewID uuid.UUID) (models.ChoreReviewResponse, error)
-	MarkNotificationsAsSeen(accountID uuid.UUID, notificationIDs []uuid.UUID) error
+	MarkNotificationsAsSeen(accountID uuid.UUID, householdID uuid.UUID, notificationIDs []uuid.UUID) error
+	CreateSession(accountID uuid.UUID, refreshTokenHash string, expiresAt time.Time) (models.Session, error)
+	GetActiveSession(sessionID uuid.UUID) (models.Session, error)
+	RotateSession(refreshTokenHash string, newRefreshTokenHash string, expiresAt time.Time) (models.Session, error)
+	RevokeSession(sessionID uuid.UUID) error
+	GetHouseholdMembership(accountID uuid.UUID, householdID uuid.UUID) (models.AccountHousehold, error)
+	EntityInHousehold(entity HouseholdEntity, entityID uuid.UUID, householdID uuid.UUID) (bool, error)
+	UpdateAccountEmail(accountID uuid.UUID, email string) error
+	CreateHouseholdInvite(invite *models.HouseholdInvite) error
+	GetActiveHouseholdInvites(householdID uuid.UUID) ([]models.HouseholdInvite, error)
+	RevokeHouseholdInvite(inviteID uuid.UUID, actorID uuid.UUID) error
+	AcceptHouseholdInvite(code string, accountID uuid.UUID) (models.Household, error)
+	UpdateMemberRole(householdID uuid.UUID, accountID uuid.UUID, role models.HouseholdRole, actorID uuid.UUID) error
+	TransferHouseholdOwnership(householdID uuid.UUID, fromAccountID uuid.UUID, toAccountID uuid.UUID) error
+	RemoveHouseholdMember(householdID uuid.UUID, memberID uuid.UUID, actorID uuid.UUID, settleBalances bool) (models.MemberDepartureResponse, error)
+	UpdateAccountProfile(accountID uuid.UUID, update models.UpdateAccountRequestBody) (models.AccountResponse, error)
+	DeleteAccount(accountID uuid.UUID, settleBalances bool) (models.MemberDepartureResponse, error)
+	ExportAccountData(accountID uuid.UUID) (models.AccountDataExport, error)
+	GetJoinAttempts(householdID uuid.UUID) ([]models.JoinAttemptResponse, error)
+	UpdateHousehold(householdID uuid.UUID, update models.UpdateHouseholdRequestBody, actorID uuid.UUID) (models.HouseholdResponse, error)
+	DeleteHousehold(householdID uuid.UUID, actorID uuid.UUID) error
+	CreatePersonalAccessToken(token *models.PersonalAccessToken) error
+	GetPersonalAccessTokens(accountID uuid.UUID) ([]models.PersonalAccessToken, error)
+	RevokePersonalAccessToken(accountID uuid.UUID, tokenID uuid.UUID) error
+	GetActivePersonalAccessToken(tokenHash string) (models.PersonalAccessToken, error)
+	GetAuditLog(householdID uuid.UUID, filter AuditLogFilter) (models.AuditLogPageResponse, error)
+	MarkOverdueAssignments(now time.Time) (int, error)
+	ExtendRecurringAssignments(now time.Time, horizonDays int) (int, error)
+	UpdateChore(choreID uuid.UUID, update ChoreUpdate, actorID uuid.UUID) (models.ChoreResponse, error)
+	ArchiveChore(choreID uuid.UUID, actorID uuid.UUID) (models.ChoreResponse, error)
+	RestoreChore(choreID uuid.UUID, actorID uuid.UUID) (models.ChoreResponse, error)
+	GetArchivedChores(householdID uuid.UUID) ([]models.ChoreResponse, error)
+	CreateChoreSwap(swap *models.ChoreSwap) error
+	GetChoreSwaps(householdID uuid.UUID, accountID uuid.UUID, status models.SwapStatus) ([]models.ChoreSwapResponse, error)
+	AcceptChoreSwap(swapID uuid.UUID, accountID uuid.UUID) (models.ChoreSwapResponse, error)
+	DeclineChoreSwap(swapID uuid.UUID, accountID uuid.UUID) (models.ChoreSwapResponse, error)
+	CancelChoreSwap(swapID uuid.UUID, accountID uuid.UUID) (models.ChoreSwapResponse, error)
+	ExpireChoreSwaps(now time.Time) (int, error)
+	CreateMemberAvailability(availability *models.MemberAvailability) (models.MemberAvailabilityResponse, error)
+	GetMemberAvailability(householdID uuid.UUID) ([]models.MemberAvailabilityResponse, error)
+	DeleteMemberAvailability(availabilityID uuid.UUID, accountID uuid.UUID) error
+	GetCoverageReport(householdID uuid.UUID, month time.Time) (models.CoverageReportResponse, error)
+	CreateChoreTemplate(template *models.ChoreTemplate) error
+	GetChoreTemplates(householdID uuid.UUID, accountID uuid.UUID) ([]models.ChoreTemplateResponse, error)
+	InstantiateChoreTemplate(templateID uuid.UUID, householdID uuid.UUID, instance TemplateInstance, actorID uuid.UUID) (*models.Chore, error)
+	DeleteChoreTemplate(templateID uuid.UUID, householdID uuid.UUID, actorID uuid.UUID) error
+	UpdateChecklist(choreID uuid.UUID, items []ChecklistItemInput, requireAll *bool, actorID uuid.UUID) (models.ChecklistResponse, error)
+	GetChoreChecklist(choreID uuid.UUID) (models.ChecklistResponse, error)
+	GetAssignmentChecklist(accountChoreID uuid.UUID) (models.ChecklistResponse, error)
+	CheckChecklistItem(accountChoreID uuid.UUID, itemID uuid.UUID, checked bool, actorID uuid.UUID) (models.ChecklistResponse, error)
+	GetAssignmentPhotos(accountChoreID uuid.UUID) ([]models.ChorePhotoResponse, error)
+	GetAssignmentReviews(accountChoreID uuid.UUID) (models.ReviewListResponse, error)
+	GetChoreReviews(choreID uuid.UUID) (models.ReviewListResponse, error)
+	GetMemberReviews(householdID uuid.UUID, memberID uuid.UUID) (models.ReviewListResponse, error)
+	UpdateChoreDependencies(choreID uuid.UUID, prerequisiteIDs []uuid.UUID, actorID uuid.UUID) (models.ChoreDependenciesResponse, error)
+	GetChoreDependencies(choreID uuid.UUID) (models.ChoreDependenciesResponse, error)
+	UndoChoreCompletion(accountChoreID uuid.UUID, actorID uuid.UUID, window time.Duration) error
 }
 
 type dbService struct {
-	db *gorm.DB
+	db          *gorm.DB
+	photos      storage.BlobStore
+	horizonDays int // How many days ahead recurring chores are planned
 }
 
-func NewDBService(connUrl string) DBService {
+func NewDBService(connUrl string, photos storage.BlobStore, horizonDays int) DBService {
+	if horizonDays <= 0 {
+		horizonDays = DefaultHorizonDays
+	}
+
 	db, err := gorm.Open(postgres.Open(connUrl), &gorm.Config{})
 	if err != nil {
 		panic("failed to connect database")
@@ -57,8 +122,41 @@ func NewDBService(connUrl string) DBService {
 		&models.Notification{},
 		&models.AccountNotification{},
 		&models.ChoreReview{},
+		&models.Session{},
+		&models.HouseholdInvite{},
+		&models.JoinAttempt{},
+		&models.PersonalAccessToken{},
+		&models.AuditLog{},
+		&models.ChoreSwap{},
+		&models.MemberAvailability{},
+		&models.ChoreTemplate{},
+		&models.ChecklistItem{},
+		&models.AssignmentChecklistItem{},
+		&models.ChorePhoto{},
+		&models.ChoreDependency{},
+		&models.ChoreCompletion{},
 	)
-	return &dbService{db: db}
+
+	// The audit log is append-only, even for code that bypasses the service
+	db.Exec(`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs is append-only';
+		END;
+	$$ LANGUAGE plpgsql`)
+	db.Exec(`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`)
+	db.Exec(`CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+		FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only()`)
+
+	// Households created before roles existed make their earliest member the owner
+	db.Exec(`UPDATE account_households SET role = ? WHERE id IN (
+		SELECT DISTINCT ON (household_id) id FROM account_households ah
+		WHERE NOT EXISTS (
+			SELECT 1 FROM account_households o WHERE o.household_id = ah.household_id AND o.role = ?
+		)
+		ORDER BY household_id, created_at
+	)`, models.HouseholdRoleOwner, models.HouseholdRoleOwner)
+
+	return &dbService{db: db, photos: photos, horizonDays: horizonDays}
 }
 
 func (s *dbService) CreateAccount(account *models.Account) (models.AccountResponse, error) {
@@ -66,9 +164,12 @@ func (s *dbService) CreateAccount(account *models.Account) (models.AccountRespon
 		return models.AccountResponse{}, err
 	}
 	return models.AccountResponse{
-		ID:       account.ID,
-		Name:     account.Name,
-		GoogleId: account.GoogleId,
+		ID:        account.ID,
+		Name:      account.Name,
+		GoogleId:  account.GoogleId,
+		Email:     account.Email,
+		AvatarURL: account.AvatarURL,
+		Timezone:  account.Timezone,
 	}, nil
 }
 
@@ -79,9 +180,12 @@ func (s *dbService) GetAccountByGoogleId(googleId string) (models.AccountRespons
 		return models.AccountResponse{}, err
 	}
 	return models.AccountResponse{
-		ID:       account.ID,
-		Name:     account.Name,
-		GoogleId: account.GoogleId,
+		ID:        account.ID,
+		Name:      account.Name,
+		GoogleId:  account.GoogleId,
+		Email:     account.Email,
+		AvatarURL: account.AvatarURL,
+		Timezone:  account.Timezone,
 	}, nil
 }
 
@@ -92,18 +196,53 @@ func (s *dbService) GetAccount(accountId uuid.UUID) (models.AccountResponse, err
 		return models.AccountResponse{}, err
 	}
 	return models.AccountResponse{
-		ID:       account.ID,
-		Name:     account.Name,
-		GoogleId: account.GoogleId,
+		ID:        account.ID,
+		Name:      account.Name,
+		GoogleId:  account.GoogleId,
+		Email:     account.Email,
+		AvatarURL: account.AvatarURL,
+		Timezone:  account.Timezone,
 	}, nil
 }
 
-func (s *dbService) CreateChore(chore *models.Chore, assignees []uuid.UUID, schedule []models.ChoreSchedule) error {
+func (s *dbService) UpdateAccountEmail(accountID uuid.UUID, email string) error {
+	return s.db.Model(&models.Account{ID: accountID}).Update("email", email).Error
+}
+
+func (s *dbService) CreateChore(chore *models.Chore, assignees []uuid.UUID, schedule []models.ChoreSchedule, actorID uuid.UUID) error {
+	if len(assignees) == 0 {
+		return ErrNoAssignees
+	}
+
 	tx := s.db.Begin()
 	if tx.Error != nil {
 		return tx.Error
 	}
 
+	if err := ensureHouseholdMembers(tx, chore.HouseholdID, assignees); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if chore.Strategy == "" {
+		chore.Strategy = models.StrategyRoundRobin
+	}
+	if !validStrategy(chore.Strategy) {
+		tx.Rollback()
+		return ErrInvalidStrategy
+	}
+	if chore.EstimatedMinutes < 0 {
+		tx.Rollback()
+		return ErrInvalidEstimate
+	}
+
+	if chore.Type == models.ChoreTypeRecurring {
+		if err := prepareRecurrence(chore, schedule); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	// Create the chore
 	if err := tx.Create(chore).Error; err != nil {
 		tx.Rollback()
@@ -156,7 +295,7 @@ func (s *dbService) CreateChore(chore *models.Chore, assignees []uuid.UUID, sche
 		}
 
 		// Generate initial assignments and get first assignment ID
-		firstAssignmentID, err := s.generateInitialAssignments(tx, chore, assignees)
+		firstAssignmentID, err := s.generateInitialAssignments(tx, chore, actorID)
 		if err != nil {
 			tx.Rollback()
 			return err
@@ -164,6 +303,19 @@ func (s *dbService) CreateChore(chore *models.Chore, assignees []uuid.UUID, sche
 		accountChoreID = firstAssignmentID
 	}
 
+	after := choreSnapshot(*chore)
+	after["assigneeIds"] = assignees
+	if err := recordAudit(tx, models.AuditLog{
+		HouseholdID: chore.HouseholdID,
+		ActorID:     &actorID,
+		Action:      models.AuditActionChoreCreated,
+		EntityType:  models.AuditEntityChore,
+		EntityID:    chore.ID,
+	}, nil, after); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Commit the transaction first
 	if err := tx.Commit().Error; err != nil {
 		return err
@@ -192,67 +344,24 @@ func (s *dbService) CreateChore(chore *models.Chore, assignees []uuid.UUID, sche
 	return nil
 }
 
-func (s *dbService) generateInitialAssignments(tx *gorm.DB, chore *models.Chore, assignees []uuid.UUID) (*uuid.UUID, error) {
-	// Get the schedule
-	var schedules []models.ChoreSchedule
-	if err := tx.Where("chore_id = ?", chore.ID).Find(&schedules).Error; err != nil {
+func (s *dbService) generateInitialAssignments(tx *gorm.DB, chore *models.Chore, actorID uuid.UUID) (*uuid.UUID, error) {
+	// Plan the first week, the earliest assignment is due now and the rest are PLANNED
+	created, err := s.extendAssignments(tx, chore, time.Now().AddDate(0, 0, s.horizonDays), &actorID)
+	if err != nil || len(created) == 0 {
 		return nil, err
 	}
 
-	// Generate assignments for next week only
-	startDate := time.Now()
-	endDate := startDate.AddDate(0, 0, 7)
-	assigneeIndex := 0
-	isFirstAssignment := true
-
-	var firstAssignmentID *uuid.UUID
-
-	for date := startDate; date.Before(endDate); date = date.AddDate(0, 0, 1) {
-		weekday := int(date.Weekday())
-		if weekday == 0 {
-			weekday = 7
-		}
-
-		// Check if this day is in schedule
-		for _, sched := range schedules {
-			if sched.DayOfWeek == weekday {
-				// Set due date to end of the selected day (23:59:59)
-				dueDate := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
-
-				accountChoreId := uuid.New()
-				// First assignment is PENDING, rest are PLANNED
-				status := models.AssignmentStatusPlanned
-				if isFirstAssignment {
-					firstAssignmentID = &accountChoreId
-					status = models.AssignmentStatusPending
-					isFirstAssignment = false
-				}
-
-				accountChore := models.AccountChore{
-					ID:           accountChoreId,
-					ChoreID:       chore.ID,
-					AccountID:     assignees[assigneeIndex],
-					HouseholdID:   chore.HouseholdID,
-					DueDate:       dueDate,
-					Status:        status,
-					RotationOrder: assigneeIndex,
-					Points:        chore.Points,
-				}
-
-				if err := tx.Create(&accountChore).Error; err != nil {
-					return nil, err
-				}
-
-				assigneeIndex = (assigneeIndex + 1) % len(assignees)
-			}
-		}
+	firstAssignmentID := created[0]
+	if err := tx.Model(&models.AccountChore{}).
+		Where("id = ?", firstAssignmentID).
+		Update("status", models.AssignmentStatusPending).Error; err != nil {
+		return nil, err
 	}
 
-	return firstAssignmentID, nil
+	return &firstAssignmentID, nil
 }
 
-
-func (s *dbService) CreateHousehold(household *models.Household) error {
+func (s *dbService) CreateHousehold(household *models.Household, ownerID uuid.UUID) error {
 	// Generate hash
 	hash, err := bcrypt.GenerateFromPassword([]byte(household.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -261,33 +370,114 @@ func (s *dbService) CreateHousehold(household *models.Household) error {
 	
 	// Set the hashed password
 	household.Password = string(hash)
+
+	if household.Timezone == "" {
+		household.Timezone = "UTC"
+	}
+	if !validTimezone(household.Timezone) {
+		return ErrInvalidTimezone
+	}
 	
-	return s.db.Create(household).Error
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(household).Error; err != nil {
+			return err
+		}
+
+		// The creator owns the household
+		if err := tx.Create(&models.AccountHousehold{
+			AccountID:   ownerID,
+			HouseholdID: household.ID,
+			Role:        models.HouseholdRoleOwner,
+		}).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: household.ID,
+			ActorID:     &ownerID,
+			Action:      models.AuditActionHouseholdCreated,
+			EntityType:  models.AuditEntityHousehold,
+			EntityID:    household.ID,
+		}, nil, map[string]interface{}{"name": household.Name, "timezone": household.Timezone})
+	})
 }
 
-func (s *dbService) JoinHousehold(householdId uuid.UUID, accountId uuid.UUID, password string) error {
+func (s *dbService) JoinHousehold(householdId uuid.UUID, accountId uuid.UUID, password string, clientIP string) error {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Serialize attempts per household and account so concurrent guesses can't skip the backoff
+	for _, key := range []uuid.UUID{householdId, accountId} {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key.String()).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := checkJoinThrottle(tx, householdId, accountId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Find household
 	var household models.Household
-	if err := s.db.First(&household, householdId).Error; err != nil {
+	if err := tx.First(&household, householdId).Error; err != nil {
+		tx.Rollback()
 		return err
 	}
 
+	attempt := models.JoinAttempt{
+		HouseholdID: householdId,
+		AccountID:   accountId,
+		IPAddress:   clientIP,
+	}
+
 	// Compare password
 	if err := bcrypt.CompareHashAndPassword([]byte(household.Password), []byte(password)); err != nil {
+		// Keep the failed attempt so it counts towards the backoff
+		if err := tx.Create(&attempt).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return err
+		}
 		return errors.New("invalid password")
 	}
 
 	// Create association
-	result := s.db.Create(&models.AccountHousehold{
+	membership := models.AccountHousehold{
 		AccountID: accountId,
 		HouseholdID: householdId,
-	})
+		Role:        models.HouseholdRoleMember,
+	}
+	result := tx.Create(&membership)
 
 	if result.Error != nil {
+		tx.Rollback()
 		return result.Error
 	}
 
-	return nil
+	if err := recordAudit(tx, models.AuditLog{
+		HouseholdID: householdId,
+		ActorID:     &accountId,
+		Action:      models.AuditActionMemberJoined,
+		EntityType:  models.AuditEntityHouseholdMember,
+		EntityID:    accountId,
+	}, nil, memberSnapshot(membership)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	attempt.Succeeded = true
+	if err := tx.Create(&attempt).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
 func (s *dbService) GetAccountHouseholds(accountId uuid.UUID) ([]models.HouseholdResponse, error) {
@@ -302,8 +492,9 @@ func (s *dbService) GetAccountHouseholds(accountId uuid.UUID) ([]models.Househol
 	response := make([]models.HouseholdResponse, len(households))
 	for i, h := range households {
 		response[i] = models.HouseholdResponse{
-			ID:   h.ID,
-			Name: h.Name,
+			ID:       h.ID,
+			Name:     h.Name,
+			Timezone: h.Timezone,
 		}
 	}
 	return response, nil
@@ -311,21 +502,23 @@ func (s *dbService) GetAccountHouseholds(accountId uuid.UUID) ([]models.Househol
 
 func (s *dbService) GetAccountChores(accountId uuid.UUID, householdId uuid.UUID) ([]models.AccountChoreResponse, error) {
 	var accountChores []models.AccountChore
-	
-	// Get current month's start and end dates
-	now := time.Now()
-	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	currentMonthEnd := currentMonthStart.AddDate(0, 1, 0).Add(-time.Second)
 
-	// Query for both pending and completed chores
-	err := s.db.Preload("Chore").Preload("Account").
+	loc, err := householdLocation(s.db, householdId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get current month's start and end in the household's timezone
+	currentMonthStart, nextMonthStart := monthBounds(time.Now(), loc)
+
+	// Query for both pending and completed chores, and those waiting for review
+	err = s.db.Preload("Chore").Preload("Account").
 		Where("account_id = ? AND household_id = ?", accountId, householdId).
-		Where("(status = ? OR status = ? OR (status = ? AND completed_at BETWEEN ? AND ?))",
-			models.AssignmentStatusPending,
-			models.AssignmentStatusPlanned,
+		Where("(status IN ? OR (status = ? AND completed_at >= ? AND completed_at < ?))",
+			append([]models.AssignmentStatus{models.AssignmentStatusPendingReview}, openAssignmentStatuses...),
 			models.AssignmentStatusCompleted,
 			currentMonthStart,
-			currentMonthEnd).
+			nextMonthStart).
 		Order("due_date ASC").
 		Find(&accountChores).Error
 
@@ -344,16 +537,21 @@ func (s *dbService) GetAccountChores(accountId uuid.UUID, householdId uuid.UUID)
 			Status:      ac.Status,
 			CompletedAt: ac.CompletedAt,
 			Points:      ac.Points,
+			RejectionReason: ac.RejectionReason,
 			Chore: models.ChoreResponse{
 				ID:          ac.Chore.ID,
 				Title:       ac.Chore.Title,
 				Description: ac.Chore.Description,
 				Type:        ac.Chore.Type,
+				Recurrence:  ac.Chore.Recurrence,
 				HouseholdID: ac.Chore.HouseholdID,
 				CreatedAt:   ac.Chore.CreatedAt,
 			},
 		}
 	}
+	if err := s.annotateBlocked(response); err != nil {
+		return nil, err
+	}
 	return response, nil
 }
 
@@ -368,9 +566,8 @@ func (s *dbService) GetHouseholdChores(householdId uuid.UUID) ([]models.AccountC
 		Preload("Account").
 		Joins("JOIN chores ON chores.id = account_chores.chore_id").
 		Where("account_chores.household_id = ?", householdId).
-		Where("(status = ? OR status = ?) AND due_date BETWEEN ? AND ?",
-			models.AssignmentStatusPending,
-			models.AssignmentStatusCompleted,
+		Where("status IN ? AND due_date BETWEEN ? AND ?",
+			[]models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusPendingReview, models.AssignmentStatusCompleted},
 			now, nextWeek).
 		Order("due_date ASC").
 		Find(&accountChores).Error
@@ -389,16 +586,21 @@ func (s *dbService) GetHouseholdChores(householdId uuid.UUID) ([]models.AccountC
 			Status:      ac.Status,
 			CompletedAt: ac.CompletedAt,
 			Points:      ac.Points,
+			RejectionReason: ac.RejectionReason,
 			Chore: models.ChoreResponse{
 				ID:          ac.Chore.ID,
 				Title:       ac.Chore.Title,
 				Description: ac.Chore.Description,
 				Type:        ac.Chore.Type,
+				Recurrence:  ac.Chore.Recurrence,
 				HouseholdID: ac.Chore.HouseholdID,
 				CreatedAt:   ac.Chore.CreatedAt,
 			},
 		}
 	}
+	if err := s.annotateBlocked(response); err != nil {
+		return nil, err
+	}
 	return response, nil
 }
 
@@ -409,19 +611,22 @@ func (s *dbService) GetHouseholdLeaderboard(householdId uuid.UUID) ([]models.Lea
 		TotalPoints uint
 	}
 
-	// Get current month's start and end dates
-	now := time.Now()
-	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	currentMonthEnd := currentMonthStart.AddDate(0, 1, 0).Add(-time.Second)
+	loc, err := householdLocation(s.db, householdId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get current month's start and end in the household's timezone
+	currentMonthStart, nextMonthStart := monthBounds(time.Now(), loc)
 
-	err := s.db.Table("account_chores").
+	err = s.db.Table("account_chores").
 		Select("account_chores.account_id, accounts.name as account_name, COALESCE(SUM(account_chores.points), 0) as total_points").
 		Joins("JOIN accounts ON accounts.id = account_chores.account_id").
-		Where("account_chores.household_id = ? AND account_chores.status = ? AND account_chores.completed_at BETWEEN ? AND ?",
+		Where("account_chores.household_id = ? AND account_chores.status = ? AND account_chores.completed_at >= ? AND account_chores.completed_at < ?",
 			householdId,
 			models.AssignmentStatusCompleted,
 			currentMonthStart,
-			currentMonthEnd).
+			nextMonthStart).
 		Group("account_chores.account_id, accounts.name").
 		Order("total_points DESC").
 		Scan(&entries).Error
@@ -442,10 +647,11 @@ func (s *dbService) GetHouseholdLeaderboard(householdId uuid.UUID) ([]models.Lea
 }
 
 func (s *dbService) GetHouseholdMembers(householdId uuid.UUID) ([]models.HouseholdMemberResponse, error) {
-	var members []models.Account
-	err := s.db.Model(&models.Household{ID: householdId}).
-		Association("Members").
-		Find(&members)
+	var members []models.AccountHousehold
+	err := s.db.Preload("Account").
+		Where("household_id = ?", householdId).
+		Order("created_at ASC").
+		Find(&members).Error
 	if err != nil {
 		return nil, err
 	}
@@ -453,14 +659,32 @@ func (s *dbService) GetHouseholdMembers(householdId uuid.UUID) ([]models.Househo
 	response := make([]models.HouseholdMemberResponse, len(members))
 	for i, m := range members {
 		response[i] = models.HouseholdMemberResponse{
-			ID:   m.ID,
-			Name: m.Name,
+			ID:   m.Account.ID,
+			Name: m.Account.Name,
+			Role: m.Role,
 		}
 	}
 	return response, nil
 }
 
-func (s *dbService) CompleteChore(accountChoreId uuid.UUID) error {
+func (s *dbService) CompleteChore(accountChoreId uuid.UUID, actorID uuid.UUID, photos []PhotoUpload) (err error) {
+	prepared, err := preparePhotos(photos)
+	if err != nil {
+		return err
+	}
+
+	// Photos and notifications point at the completion so undoing it finds them
+	now := time.Now()
+	completionID := uuid.New()
+
+	// Uploads can't be rolled back with the transaction, so remove them by hand
+	var storedKeys []string
+	defer func() {
+		if err != nil {
+			s.deleteBlobs(storedKeys)
+		}
+	}()
+
 	tx := s.db.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -469,12 +693,40 @@ func (s *dbService) CompleteChore(accountChoreId uuid.UUID) error {
 	// Get the account chore with related data
 	var accountChore models.AccountChore
 	if err := tx.Preload("Account").Preload("Chore").
-		Where("id = ? AND status = ?", accountChoreId, models.AssignmentStatusPending).
+		Where("id = ? AND status IN ?", accountChoreId, []models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusOverdue}).
 		First(&accountChore).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	if accountChore.Chore.RequireChecklist {
+		complete, err := checklistComplete(tx, accountChore)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if !complete {
+			tx.Rollback()
+			return ErrChecklistIncomplete
+		}
+	}
+
+	blocking, err := blockingPrerequisites(tx, accountChore)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(blocking) > 0 {
+		tx.Rollback()
+		return ErrAssignmentBlocked
+	}
+
+	storedKeys, err = s.storePhotos(tx, accountChore, completionID, actorID, prepared)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Get household members for notification
 	var householdMembers []uuid.UUID
 	if err := tx.Model(&models.AccountHousehold{}).
@@ -490,6 +742,10 @@ func (s *dbService) CompleteChore(accountChoreId uuid.UUID) error {
 		AccountID:      accountChore.AccountID,
 		ChoreID:        &accountChore.ChoreID,
 		AccountChoreID: &accountChore.ID,
+		CompletionID:   &completionID,
+	}
+	if accountChore.Chore.RequireReview {
+		notification.Action = models.NotificationActionChoreAwaitingReview
 	}
 
 	if err := s.CreateNotification(notification, householdMembers, accountChore.HouseholdID); err != nil {
@@ -497,22 +753,53 @@ func (s *dbService) CompleteChore(accountChoreId uuid.UUID) error {
 		return err
 	}
 
-	now := time.Now()
+	before := assignmentSnapshot(accountChore)
+	previousStatus := accountChore.Status
 	accountChore.Status = models.AssignmentStatusCompleted
 	accountChore.CompletedAt = &now
+	if accountChore.Chore.RequireReview {
+		// Points only count once another member approves
+		accountChore.Status = models.AssignmentStatusPendingReview
+	}
 
 	if err := tx.Save(&accountChore).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	if err := recordAudit(tx, models.AuditLog{
+		HouseholdID: accountChore.HouseholdID,
+		ActorID:     &actorID,
+		Action:      models.AuditActionChoreCompleted,
+		EntityType:  models.AuditEntityAccountChore,
+		EntityID:    accountChore.ID,
+	}, before, assignmentSnapshot(accountChore)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var effects completionEffects
 	if accountChore.Chore.Type == models.ChoreTypeRecurring {
+		// Remember how the rotation looked so the completion can be undone
+		rotationBefore, err := rotationState(tx, accountChore.ChoreID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
 		// Handle recurring chore logic
-		nextPendingID, err := s.handleRecurringChoreCompletion(tx, &accountChore.Chore, &accountChore)
+		nextPendingID, err := s.handleRecurringChoreCompletion(tx, &accountChore.Chore, &accountChore, actorID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		rotationAfter, err := rotationState(tx, accountChore.ChoreID)
 		if err != nil {
 			tx.Rollback()
 			return err
 		}
+		effects.Rotation = rotationChanges(rotationBefore, rotationAfter)
 
 		// Create notification for next pending assignment if one exists
 		if nextPendingID != nil {
@@ -521,6 +808,7 @@ func (s *dbService) CompleteChore(accountChoreId uuid.UUID) error {
 				AccountID:      accountChore.AccountID,
 				ChoreID:        &accountChore.ChoreID,
 				AccountChoreID: nextPendingID,
+				CompletionID:   &completionID,
 			}
 
 			if err := s.CreateNotification(notification, householdMembers, accountChore.HouseholdID); err != nil {
@@ -530,100 +818,55 @@ func (s *dbService) CompleteChore(accountChoreId uuid.UUID) error {
 		}
 	}
 
-	return tx.Commit().Error
-}
-
-func (s *dbService) handleRecurringChoreCompletion(tx *gorm.DB, chore *models.Chore, completedChore *models.AccountChore) (*uuid.UUID, error) {
-	// Get the rotation for this chore
-	var rotations []models.ChoreRotation
-	if err := tx.Where("chore_id = ?", chore.ID).Order("rotation_order").Find(&rotations).Error; err != nil {
-		return nil, err
+	unblocked, err := unblockedBy(tx, accountChore)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, assignment := range unblocked {
+		effects.Unblocked = append(effects.Unblocked, assignment.ID)
 	}
 
-	// Get the schedule
-	var schedules []models.ChoreSchedule
-	if err := tx.Where("chore_id = ?", chore.ID).Find(&schedules).Error; err != nil {
-		return nil, err
+	if err := recordCompletion(tx, completionID, accountChore, previousStatus, actorID, effects); err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	// Get the next occurrence date based on completion time
-	nextDate := s.calculateNextOccurrence(*completedChore.CompletedAt)
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	storedKeys = nil // The photos belong to the completion now
+	return s.notifyUnblocked(unblocked, actorID, completionID)
+}
 
-	// Count assignments between completion and next date
-	var assignmentCount int64
-	if err := tx.Model(&models.AccountChore{}).
-		Where("chore_id = ? AND due_date > ? AND due_date < ? AND status IN (?)",
-			chore.ID,
-			completedChore.CompletedAt,
-			nextDate,
-			[]models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusPlanned}).
-		Count(&assignmentCount).Error; err != nil {
+func (s *dbService) handleRecurringChoreCompletion(tx *gorm.DB, chore *models.Chore, completedChore *models.AccountChore, actorID uuid.UUID) (*uuid.UUID, error) {
+	// Keep a week of assignments planned ahead of the completion
+	if _, err := s.extendAssignments(tx, chore, completedChore.CompletedAt.AddDate(0, 0, s.horizonDays), &actorID); err != nil {
 		return nil, err
 	}
 
-	// Calculate next rotation order based on completed assignment and intervening assignments
-	nextRotationOrder := (completedChore.RotationOrder + 1 + int(assignmentCount)) % len(rotations)
-	nextAssignee := rotations[nextRotationOrder].AccountID
-
-	// Create the next week's assignment
-	var nextWeekAssignment models.AccountChore
-	err := tx.Where("chore_id = ? AND due_date = ? AND status IN (?)",
-		chore.ID, nextDate, []models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusPlanned}).
-		First(&nextWeekAssignment).Error
-
-	if err == gorm.ErrRecordNotFound {
-		nextWeekAssignment = models.AccountChore{
-			ChoreID:       chore.ID,
-			AccountID:     nextAssignee,
-			HouseholdID:   chore.HouseholdID,
-			DueDate:       nextDate,
-			Status:        models.AssignmentStatusPlanned,
-			RotationOrder: nextRotationOrder,
-			Points:        chore.Points,
-		}
-		if err := tx.Create(&nextWeekAssignment).Error; err != nil {
+	// If completed late, move the remaining assignments onto the schedule's next dates
+	if completedChore.CompletedAt.After(completedChore.DueDate) {
+		if err := s.updateFutureAssignments(tx, chore, completedChore); err != nil {
 			return nil, err
 		}
-	} else if err != nil {
-		return nil, err
 	}
 
-	// Find and update the next immediate assignment to pending
-	var nextImmediateAssignment models.AccountChore
-	err = tx.Where("chore_id = ? AND due_date > ? AND status = ?",
-		chore.ID, completedChore.CompletedAt, models.AssignmentStatusPlanned).
-		Order("due_date").First(&nextImmediateAssignment).Error
-
-	var nextPendingID *uuid.UUID
-	if err == nil {
-		nextImmediateAssignment.Status = models.AssignmentStatusPending
-		if err := tx.Save(&nextImmediateAssignment).Error; err != nil {
-			return nil, err
-		}
-		nextPendingID = &nextImmediateAssignment.ID
-	} else if err != gorm.ErrRecordNotFound {
+	// Make the next upcoming assignment pending, unless the scheduler already did
+	next, err := promoteNextAssignment(tx, chore.ID, *completedChore.CompletedAt)
+	if err != nil {
 		return nil, err
 	}
 
-	// If completed late, update all future assignments' due dates
-	if completedChore.CompletedAt.After(completedChore.DueDate) {
-		if err := s.updateFutureAssignments(tx, chore, completedChore, schedules); err != nil {
-			return nil, err
-		}
+	var nextPendingID *uuid.UUID
+	if next != nil {
+		nextPendingID = &next.ID
 	}
 
 	return nextPendingID, nil
 }
 
-func (s *dbService) calculateNextOccurrence(lastDueDate time.Time) time.Time {	
-	// Add 7 days to get to the same weekday next week
-	nextDate := lastDueDate.AddDate(0, 0, 7)
-	
-	// Return the date with time set to end of day
-	return time.Date(nextDate.Year(), nextDate.Month(), nextDate.Day(), 23, 59, 59, 0, nextDate.Location())
-}
-
-func (s *dbService) updateFutureAssignments(tx *gorm.DB, chore *models.Chore, completedChore *models.AccountChore, schedules []models.ChoreSchedule) error {
+func (s *dbService) updateFutureAssignments(tx *gorm.DB, chore *models.Chore, completedChore *models.AccountChore) error {
 	var futureAssignments []models.AccountChore
 	if err := tx.Where("chore_id = ? AND due_date > ? AND status IN (?)",
 		chore.ID, completedChore.DueDate, []models.AssignmentStatus{models.AssignmentStatusPending, models.AssignmentStatusPlanned}).
@@ -631,20 +874,36 @@ func (s *dbService) updateFutureAssignments(tx *gorm.DB, chore *models.Chore, co
 		return err
 	}
 
-	// Calculate the new base date for the schedule
-	nextDate := s.calculateNextOccurrence(*completedChore.CompletedAt)
+	rule, start, err := choreRecurrence(tx, chore)
+	if err != nil {
+		return err
+	}
+
+	// Occurrences start the day after completion, the completed day is taken
+	from := completedChore.CompletedAt.In(start.Location()).AddDate(0, 0, 1)
+	days := rule.NextN(start, from, len(futureAssignments))
+
+	var rotations []models.ChoreRotation
+	if err := tx.Where("chore_id = ?", chore.ID).Order("rotation_order").Find(&rotations).Error; err != nil {
+		return err
+	}
+	calendar, err := loadAvailability(tx, chore.HouseholdID, from)
+	if err != nil {
+		return err
+	}
 
-	// First assignment should be pending, rest remain planned
 	for i, assignment := range futureAssignments {
-		assignment.DueDate = nextDate
-		if i == 0 {
-			assignment.Status = models.AssignmentStatusPending
+		if i >= len(days) {
+			break
 		}
+		assignment.DueDate = dueDateOn(days[i])
+		// A new date may fall while the assignee is away
+		calendar.cover(rotations, &assignment, days[i])
+		// The next assignment is promoted by the caller
+		assignment.Status = models.AssignmentStatusPlanned
 		if err := tx.Save(&assignment).Error; err != nil {
 			return err
 		}
-		// Calculate next date for subsequent assignments
-		nextDate = s.calculateNextOccurrence(nextDate)
 	}
 
 	return nil
@@ -695,6 +954,22 @@ func (s *dbService) CreateTransaction(transaction *models.Transaction) error {
 		}
 	}
 
+	if err := recordAudit(tx, models.AuditLog{
+		HouseholdID: transaction.HouseholdID,
+		ActorID:     &transaction.PaidByID,
+		Action:      models.AuditActionTransactionCreated,
+		EntityType:  models.AuditEntityTransaction,
+		EntityID:    transaction.ID,
+	}, nil, map[string]interface{}{
+		"paidById":      transaction.PaidByID,
+		"amountInCents": transaction.AmountInCents,
+		"description":   transaction.Description,
+		"spentAt":       transaction.SpentAt,
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return err
 	}
@@ -714,11 +989,21 @@ func (s *dbService) CreateTransaction(transaction *models.Transaction) error {
 }
 
 func (s *dbService) GetTransactionSummary(accountID uuid.UUID, householdID uuid.UUID, month time.Time) (models.TransactionSummary, error) {
-	startOfMonth := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
-	endOfMonth := startOfMonth.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	loc, err := householdLocation(s.db, householdID)
+	if err != nil {
+		return models.TransactionSummary{}, err
+	}
+
+	// month only carries a year and month, read them as a month in the household's
+	// timezone. The zero time means the household's current month.
+	if month.IsZero() {
+		month = time.Now().In(loc)
+	}
+	startOfMonth := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	nextMonth := startOfMonth.AddDate(0, 1, 0)
 	
 	var summary models.TransactionSummary
-	summary.Month = month
+	summary.Month = startOfMonth
 	summary.OwedDetails = []models.TransactionOwedDetail{}
 	summary.OwingDetails = []models.TransactionOwingDetail{}
 	summary.TotalOwed = 0
@@ -726,12 +1011,12 @@ func (s *dbService) GetTransactionSummary(accountID uuid.UUID, householdID uuid.
 
 	// Get all splits for the user (both owed and owing)
 	var splits []models.TransactionSplit
-	err := s.db.Where("(owed_by_id = ? OR owed_to_id = ?) AND transaction_id IN (?)",
+	err = s.db.Where("(owed_by_id = ? OR owed_to_id = ?) AND transaction_id IN (?)",
 		accountID, accountID,
 		s.db.Model(&models.Transaction{}).
 			Select("id").
-			Where("household_id = ? AND spent_at BETWEEN ? AND ?",
-				householdID, startOfMonth, endOfMonth)).
+			Where("household_id = ? AND spent_at >= ? AND spent_at < ?",
+				householdID, startOfMonth, nextMonth)).
 		Preload("Transaction").
 		Preload("OwedBy").
 		Preload("OwedTo").
@@ -858,7 +1143,7 @@ func (s *dbService) GetTransactionSummary(accountID uuid.UUID, householdID uuid.
 	return summary, nil
 }
 
-func (s *dbService) SettleTransactionSplit(splitID uuid.UUID) error {
+func (s *dbService) SettleTransactionSplit(splitID uuid.UUID, actorID uuid.UUID) error {
 	tx := s.db.Begin()
 	
 	var split models.TransactionSplit
@@ -868,6 +1153,7 @@ func (s *dbService) SettleTransactionSplit(splitID uuid.UUID) error {
 		return err
 	}
 
+	before := splitSnapshot(split)
 	split.IsSettled = true
 	now := time.Now()
 	split.SettledAt = &now
@@ -877,6 +1163,17 @@ func (s *dbService) SettleTransactionSplit(splitID uuid.UUID) error {
 		return err
 	}
 
+	if err := recordAudit(tx, models.AuditLog{
+		HouseholdID: split.Transaction.HouseholdID,
+		ActorID:     &actorID,
+		Action:      models.AuditActionSplitSettled,
+		EntityType:  models.AuditEntityTransactionSplit,
+		EntityID:    split.ID,
+	}, before, splitSnapshot(split)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	var householdMembers []uuid.UUID
 	if err := tx.Model(&models.AccountHousehold{}).
 		Where("household_id = ?", split.Transaction.HouseholdID).
@@ -931,15 +1228,32 @@ func (s *dbService) CreateNotification(notification *models.Notification, recipi
 }
 
 func (s *dbService) GetAccountNotifications(accountID uuid.UUID, householdID uuid.UUID) ([]models.NotificationResponse, error) {
+	return s.accountNotifications(s.db.Where("account_id = ? AND household_id = ?", accountID, householdID))
+}
+
+// GetDeletedHouseholdNotifications lists the account's notifications from
+// households that have since been deleted. Those can't be opened any more, so
+// this is the only place their members learn about the deletion.
+func (s *dbService) GetDeletedHouseholdNotifications(accountID uuid.UUID) ([]models.NotificationResponse, error) {
+	return s.accountNotifications(s.db.
+		Where("account_id = ? AND household_id IN (SELECT id FROM households WHERE deleted_at IS NOT NULL)", accountID))
+}
+
+func (s *dbService) accountNotifications(query *gorm.DB) ([]models.NotificationResponse, error) {
 	var accountNotifications []models.AccountNotification
-	err := s.db.Where("account_id = ? AND household_id = ?", accountID, householdID).
+	err := query.
 		Preload("Notification.Account").
+		Preload("Notification.Household", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
 		Preload("Notification.AccountChore.Chore").
+		Preload("Notification.Chore").
+		Preload("Notification.Swap.OfferedAssignment.Chore").
+		Preload("Notification.Swap.RequestedAssignment.Chore").
 		Preload("Notification.Transaction").
 		Preload("Notification.Review").
 		Preload("Notification.Split").
 		Preload("Notification.Split.OwedBy").
 		Preload("Notification.Split.OwedTo").
+		Preload("Notification.TargetAccount").
 		Order("created_at DESC").
 		Find(&accountNotifications).Error
 	if err != nil {
@@ -958,13 +1272,18 @@ func (s *dbService) GetAccountNotifications(accountID uuid.UUID, householdID uui
 				ID:   notif.Account.ID,
 				Name: notif.Account.Name,
 			},
+			RetractedAt: notif.RetractedAt,
 		}
 
 		// Add type-specific information
 		switch notif.Action {
 		case models.NotificationActionChoreAssigned, 
 			 models.NotificationActionChorePending,
-			 models.NotificationActionChoreCompleted:
+			 models.NotificationActionChoreCompleted,
+			 models.NotificationActionChoreAwaitingReview,
+			 models.NotificationActionChoreUnblocked,
+			 models.NotificationActionCompletionUndone,
+			 models.NotificationActionChoreOverdue:
 			if notif.AccountChore.ID != uuid.Nil {
 				response[i].ChoreInfo = &models.ChoreInfo{
 					ChoreID:        notif.AccountChore.ChoreID,
@@ -973,7 +1292,16 @@ func (s *dbService) GetAccountNotifications(accountID uuid.UUID, householdID uui
 					DueDate:        notif.AccountChore.DueDate,
 				}
 			}
-		case models.NotificationActionReviewSubmitted:
+		case models.NotificationActionChoreUpdated, models.NotificationActionChoreArchived:
+			if notif.Chore.ID != uuid.Nil {
+				response[i].ChoreInfo = &models.ChoreInfo{
+					ChoreID: notif.Chore.ID,
+					Title:   notif.Chore.Title,
+				}
+			}
+		case models.NotificationActionReviewSubmitted,
+			models.NotificationActionChoreApproved,
+			models.NotificationActionChoreRejected:
 			if notif.Review.ID != uuid.Nil {
 				response[i].ReviewInfo = &models.ReviewInfo{
 					ReviewID: notif.Review.ID,
@@ -1002,19 +1330,162 @@ func (s *dbService) GetAccountNotifications(accountID uuid.UUID, householdID uui
 					OwedToName: notif.Split.OwedTo.Name,
 				}
 			}
+		case models.NotificationActionMemberLeft,
+			 models.NotificationActionMemberRemoved:
+			if notif.TargetAccount.ID != uuid.Nil {
+				response[i].Member = &models.ActorInfo{
+					ID:   notif.TargetAccount.ID,
+					Name: notif.TargetAccount.Name,
+				}
+			}
+		case models.NotificationActionHouseholdDeleted:
+			if notif.Household.ID != uuid.Nil {
+				response[i].Household = &models.HouseholdInfo{
+					HouseholdID: notif.Household.ID,
+					Name:        notif.Household.Name,
+				}
+			}
+		case models.NotificationActionSwapRequested,
+			models.NotificationActionSwapAccepted,
+			models.NotificationActionSwapDeclined,
+			models.NotificationActionSwapCancelled,
+			models.NotificationActionSwapExpired:
+			if notif.Swap.ID != uuid.Nil {
+				response[i].Swap = &models.SwapInfo{
+					SwapID:       notif.Swap.ID,
+					Status:       notif.Swap.Status,
+					RequesterID:  notif.Swap.RequesterID,
+					RecipientID:  notif.Swap.RecipientID,
+					OfferedTitle: notif.Swap.OfferedAssignment.Chore.Title,
+				}
+				if notif.Swap.RequestedAssignment != nil {
+					response[i].Swap.RequestedTitle = notif.Swap.RequestedAssignment.Chore.Title
+				}
+			}
+		}
+	}
+
+	// Completions and reviews carry the photos submitted as proof
+	var photoAssignments []uuid.UUID
+	for _, notification := range response {
+		if completionAction(notification.Action) && notification.ChoreInfo != nil {
+			photoAssignments = append(photoAssignments, notification.ChoreInfo.AccountChoreID)
+		}
+		if notification.ReviewInfo != nil {
+			photoAssignments = append(photoAssignments, notification.ReviewInfo.AccountChoreID)
+		}
+	}
+	photos, err := s.assignmentPhotos(photoAssignments)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response {
+		if completionAction(response[i].Action) && response[i].ChoreInfo != nil {
+			response[i].ChoreInfo.Photos = photos[response[i].ChoreInfo.AccountChoreID]
+		}
+		if response[i].ReviewInfo != nil {
+			response[i].ReviewInfo.Photos = photos[response[i].ReviewInfo.AccountChoreID]
 		}
 	}
 	return response, nil
 }
 
-func (s *dbService) MarkNotificationAsSeen(accountID uuid.UUID, notificationID uuid.UUID) error {
+func (s *dbService) MarkNotificationAsSeen(accountID uuid.UUID, householdID uuid.UUID, notificationID uuid.UUID) error {
 	return s.db.Model(&models.AccountNotification{}).
-		Where("account_id = ? AND notification_id = ?", accountID, notificationID).
+		Where("account_id = ? AND household_id = ? AND notification_id = ?", accountID, householdID, notificationID).
 		Update("seen", true).Error
 }
 
 func (s *dbService) CreateChoreReview(review *models.ChoreReview) error {
-	err := s.db.Create(review).Error
+	if err := validateReview(*review); err != nil {
+		return err
+	}
+
+	notificationAction := models.NotificationActionReviewSubmitted
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var assignment models.AccountChore
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND household_id = ?", review.AccountChoreID, review.HouseholdID).
+			First(&assignment).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrAssignmentNotFound
+			}
+			return err
+		}
+		if assignment.AccountID == review.ReviewerID {
+			return ErrSelfReview
+		}
+		if assignment.Status != models.AssignmentStatusPendingReview && assignment.Status != models.AssignmentStatusCompleted {
+			return ErrAssignmentNotReviewable
+		}
+		// Anyone can complete an assignment, so whoever did can't approve it either
+		completion, err := currentCompletion(tx, assignment)
+		if err != nil {
+			return err
+		}
+		if completion != nil && completion.CompletedByID == review.ReviewerID {
+			return ErrSelfReview
+		}
+		reviewed, err := alreadyReviewed(tx, assignment, review.ReviewerID)
+		if err != nil {
+			return err
+		}
+		if reviewed {
+			return ErrAlreadyReviewed
+		}
+
+		missedItems := review.MissedItems
+		review.MissedItems = nil
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+
+		// Missed items have to be on the reviewed chore's checklist
+		missedIDs := make([]uuid.UUID, len(missedItems))
+		for i, item := range missedItems {
+			missedIDs[i] = item.ID
+		}
+		if len(missedIDs) > 0 {
+			if err := tx.Joins("JOIN account_chores ON account_chores.chore_id = checklist_items.chore_id").
+				Where("account_chores.id = ? AND checklist_items.id IN ?", review.AccountChoreID, missedIDs).
+				Find(&review.MissedItems).Error; err != nil {
+				return err
+			}
+			if len(review.MissedItems) != len(missedIDs) {
+				return ErrChecklistItemNotFound
+			}
+			if err := tx.Model(review).Association("MissedItems").Append(review.MissedItems); err != nil {
+				return err
+			}
+		}
+
+		if err := recordAudit(tx, models.AuditLog{
+			HouseholdID: review.HouseholdID,
+			ActorID:     &review.ReviewerID,
+			Action:      models.AuditActionReviewCreated,
+			EntityType:  models.AuditEntityChoreReview,
+			EntityID:    review.ID,
+		}, nil, map[string]interface{}{
+			"accountChoreId": review.AccountChoreID,
+			"reviewerStatus": review.ReviewerStatus,
+			"rating":         review.Rating,
+			"review":         review.Review,
+			"missedItemIds":  missedIDs,
+		}); err != nil {
+			return err
+		}
+
+		// Reviews of chores that weren't waiting for one are just feedback
+		if assignment.Status != models.AssignmentStatusPendingReview {
+			return nil
+		}
+		action, err := settleReview(tx, &assignment, *review, time.Now())
+		if err != nil {
+			return err
+		}
+		notificationAction = action
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -1027,7 +1498,7 @@ func (s *dbService) CreateChoreReview(review *models.ChoreReview) error {
 	}
 
 	notification := models.Notification{
-		Action:        models.NotificationActionReviewSubmitted,
+		Action:        notificationAction,
 		AccountID:     review.ReviewerID,
 		AccountChoreID: &review.AccountChoreID,
 		ReviewID:      &review.ID,
@@ -1044,25 +1515,20 @@ func (s *dbService) CreateChoreReview(review *models.ChoreReview) error {
 
 func (s *dbService) GetChoreReview(reviewID uuid.UUID) (models.ChoreReviewResponse, error) {
 	var review models.ChoreReview
-	err := s.db.Where("id = ?", reviewID).
-		Preload("Reviewer").
-		First(&review).Error
+	err := preloadReviews(s.db).Where("id = ?", reviewID).First(&review).Error
 	if err != nil {
 		return models.ChoreReviewResponse{}, err
 	}
 
-	return models.ChoreReviewResponse{
-		ID:             review.ID,
-		ReviewerID:     review.ReviewerID,
-		ReviewerName:   review.Reviewer.Name,
-		ReviewerStatus: review.ReviewerStatus,
-		ReviewComment:  review.Review,
-		CreatedAt:      review.CreatedAt,
-	}, nil
+	photos, err := s.assignmentPhotos([]uuid.UUID{review.AccountChoreID})
+	if err != nil {
+		return models.ChoreReviewResponse{}, err
+	}
+	return reviewResponse(review, photos[review.AccountChoreID]), nil
 }
 
-func (s *dbService) MarkNotificationsAsSeen(accountID uuid.UUID, notificationIDs []uuid.UUID) error {
+func (s *dbService) MarkNotificationsAsSeen(accountID uuid.UUID, householdID uuid.UUID, notificationIDs []uuid.UUID) error {
 	return s.db.Model(&models.AccountNotification{}).
-		Where("account_id = ? AND id IN ?", accountID, notificationIDs).
+		Where("account_id = ? AND household_id = ? AND id IN ?", accountID, householdID, notificationIDs).
 		Update("seen", true).Error
 }
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+func (s *dbService) CreateSession(accountID uuid.UUID, refreshTokenHash string, expiresAt time.Time) (models.Session, error) {
+	session := models.Session{
+		AccountID:        accountID,
+		RefreshTokenHash: refreshTokenHash,
+		ExpiresAt:        expiresAt,
+	}
+	if err := s.db.Create(&session).Error; err != nil {
+		return models.Session{}, err
+	}
+	return session, nil
+}
+
+// GetActiveSession loads a non-revoked, non-expired session along with its account.
+func (s *dbService) GetActiveSession(sessionID uuid.UUID) (models.Session, error) {
+	var session models.Session
+	err := s.db.Preload("Account").
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, time.Now()).
+		First(&session).Error
+	if err == gorm.ErrRecordNotFound {
+		return models.Session{}, ErrInvalidSession
+	}
+	if err != nil {
+		return models.Session{}, err
+	}
+	return session, nil
+}
+
+// RotateSession swaps the refresh token of an active session so each refresh
+// token can only be used once. The session row is locked so concurrent
+// refreshes with the same token cannot both succeed.
+func (s *dbService) RotateSession(refreshTokenHash string, newRefreshTokenHash string, expiresAt time.Time) (models.Session, error) {
+	var session models.Session
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("refresh_token_hash = ? AND revoked_at IS NULL AND expires_at > ?", refreshTokenHash, time.Now()).
+			First(&session).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrInvalidSession
+			}
+			return err
+		}
+
+		if err := tx.Model(&session).Updates(map[string]interface{}{
+			"refresh_token_hash": newRefreshTokenHash,
+			"expires_at":         expiresAt,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.First(&session.Account, "id = ?", session.AccountID).Error
+	})
+	if err != nil {
+		return models.Session{}, err
+	}
+	return session, nil
+}
+
+func (s *dbService) RevokeSession(sessionID uuid.UUID) error {
+	return s.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidStrategy = errors.New("unknown assignment strategy")
+
+// loadWindowDays is how far back the load based strategies look.
+const loadWindowDays = 28
+
+// AssignmentStrategy picks who does each occurrence of a recurring chore. It
+// returns an index into the chore's rotation given the previous occurrence's
+// index, or -1 when there is none. Availability is applied afterwards, so a
+// strategy may pick someone who turns out to be away.
+type AssignmentStrategy interface {
+	NextSlot(tx *gorm.DB, chore *models.Chore, rotations []models.ChoreRotation, previous int, day time.Time) (int, error)
+}
+
+var assignmentStrategies = map[models.AssignmentStrategy]AssignmentStrategy{
+	models.StrategyRoundRobin:  roundRobinStrategy{},
+	models.StrategyLeastPoints: leastLoadedStrategy{load: "account_chores.points"},
+	models.StrategyLeastEffort: leastLoadedStrategy{load: "chores.estimated_minutes"},
+	models.StrategyRandomFair:  randomFairStrategy{},
+	models.StrategyFixed:       fixedStrategy{},
+}
+
+func validStrategy(strategy models.AssignmentStrategy) bool {
+	_, ok := assignmentStrategies[strategy]
+	return ok
+}
+
+// strategyFor returns the chore's strategy, round robin for chores created
+// before strategies existed.
+func strategyFor(chore *models.Chore) AssignmentStrategy {
+	if strategy, ok := assignmentStrategies[chore.Strategy]; ok {
+		return strategy
+	}
+	return roundRobinStrategy{}
+}
+
+type roundRobinStrategy struct{}
+
+func (roundRobinStrategy) NextSlot(tx *gorm.DB, chore *models.Chore, rotations []models.ChoreRotation, previous int, day time.Time) (int, error) {
+	return (previous + 1) % len(rotations), nil
+}
+
+type fixedStrategy struct{}
+
+func (fixedStrategy) NextSlot(tx *gorm.DB, chore *models.Chore, rotations []models.ChoreRotation, previous int, day time.Time) (int, error) {
+	return 0, nil
+}
+
+// leastLoadedStrategy picks the rotation member with the lowest load over the
+// window ending on day, across every chore in the household. load is the
+// column summed per assignment. Ties go to whoever is next in rotation order.
+type leastLoadedStrategy struct {
+	load string
+}
+
+func (s leastLoadedStrategy) NextSlot(tx *gorm.DB, chore *models.Chore, rotations []models.ChoreRotation, previous int, day time.Time) (int, error) {
+	var rows []struct {
+		AccountID uuid.UUID
+		Total     int
+	}
+	if err := tx.Model(&models.AccountChore{}).
+		Select("account_chores.account_id, COALESCE(SUM("+s.load+"), 0) as total").
+		Joins("JOIN chores ON chores.id = account_chores.chore_id").
+		Where("account_chores.household_id = ? AND account_chores.account_id IN ? AND account_chores.status <> ?",
+			chore.HouseholdID, rotationMembers(rotations), models.AssignmentStatusCancelled).
+		Where("account_chores.due_date >= ? AND account_chores.due_date < ?",
+			day.AddDate(0, 0, -loadWindowDays), day.AddDate(0, 0, 1)).
+		Group("account_chores.account_id").
+		Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+
+	loads := map[uuid.UUID]int{}
+	for _, row := range rows {
+		loads[row.AccountID] = row.Total
+	}
+	return lowestSlot(rotations, previous, loads), nil
+}
+
+// randomFairStrategy picks at random among the rotation members who have had
+// the fewest turns at this chore over the window, so everyone takes a turn
+// before anyone gets a second one, just not in a fixed order.
+type randomFairStrategy struct{}
+
+func (randomFairStrategy) NextSlot(tx *gorm.DB, chore *models.Chore, rotations []models.ChoreRotation, previous int, day time.Time) (int, error) {
+	var rows []struct {
+		AccountID uuid.UUID
+		Total     int
+	}
+	if err := tx.Model(&models.AccountChore{}).
+		Select("account_id, COUNT(*) as total").
+		Where("chore_id = ? AND account_id IN ? AND status <> ?",
+			chore.ID, rotationMembers(rotations), models.AssignmentStatusCancelled).
+		Where("due_date >= ? AND due_date < ?", day.AddDate(0, 0, -loadWindowDays), day.AddDate(0, 0, 1)).
+		Group("account_id").
+		Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+
+	turns := map[uuid.UUID]int{}
+	for _, row := range rows {
+		turns[row.AccountID] = row.Total
+	}
+
+	fewest := -1
+	var candidates []int
+	for i, rotation := range rotations {
+		switch count := turns[rotation.AccountID]; {
+		case fewest == -1 || count < fewest:
+			fewest = count
+			candidates = []int{i}
+		case count == fewest:
+			candidates = append(candidates, i)
+		}
+	}
+	return candidates[rand.IntN(len(candidates))], nil
+}
+
+func rotationMembers(rotations []models.ChoreRotation) []uuid.UUID {
+	members := make([]uuid.UUID, len(rotations))
+	for i, rotation := range rotations {
+		members[i] = rotation.AccountID
+	}
+	return members
+}
+
+// lowestSlot returns the slot with the lowest value, starting the search after
+// previous so ties fall back to rotation order.
+func lowestSlot(rotations []models.ChoreRotation, previous int, values map[uuid.UUID]int) int {
+	best := -1
+	for i := 1; i <= len(rotations); i++ {
+		slot := (previous + i + len(rotations)) % len(rotations)
+		if best == -1 || values[rotations[slot].AccountID] < values[rotations[best].AccountID] {
+			best = slot
+		}
+	}
+	return best
+}
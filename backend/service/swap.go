@@ -0,0 +1,533 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSwapNotFound     = errors.New("swap request not found")
+	ErrSwapNotPending   = errors.New("swap request has already been answered")
+	ErrSwapExpired      = errors.New("swap request has expired")
+	ErrInvalidSwap      = errors.New("assignments can only be swapped between two members' open assignments")
+	ErrSwapConflict     = errors.New("assignment already has a pending swap request")
+	ErrSwapNotRecipient = errors.New("only the recipient can answer a swap request")
+	ErrSwapNotRequester = errors.New("only the requester can cancel a swap request")
+)
+
+// swapJobLockKey guards ExpireChoreSwaps, alongside the other scheduled jobs.
+const swapJobLockKey = 720003
+
+// swappableStatuses are the assignments that can still change hands.
+var swappableStatuses = []models.AssignmentStatus{
+	models.AssignmentStatusPending,
+	models.AssignmentStatusPlanned,
+}
+
+// CreateChoreSwap asks the recipient to take the offered assignment, in
+// exchange for the requested one when set. The request expires at the
+// earlier of its expiry and the first due date involved.
+func (s *dbService) CreateChoreSwap(swap *models.ChoreSwap) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if swap.RecipientID == swap.RequesterID {
+			return ErrInvalidSwap
+		}
+		if err := ensureHouseholdMembers(tx, swap.HouseholdID, []uuid.UUID{swap.RecipientID}); err != nil {
+			return err
+		}
+
+		offered, err := swappableAssignment(tx, swap.OfferedAssignmentID, swap.HouseholdID, swap.RequesterID)
+		if err != nil {
+			return err
+		}
+		assignmentIDs := []uuid.UUID{offered.ID}
+		if offered.DueDate.Before(swap.ExpiresAt) {
+			swap.ExpiresAt = offered.DueDate
+		}
+
+		if swap.RequestedAssignmentID != nil {
+			requested, err := swappableAssignment(tx, *swap.RequestedAssignmentID, swap.HouseholdID, swap.RecipientID)
+			if err != nil {
+				return err
+			}
+			assignmentIDs = append(assignmentIDs, requested.ID)
+			if requested.DueDate.Before(swap.ExpiresAt) {
+				swap.ExpiresAt = requested.DueDate
+			}
+		}
+		if !swap.ExpiresAt.After(time.Now()) {
+			return ErrSwapExpired
+		}
+
+		var pending int64
+		if err := tx.Model(&models.ChoreSwap{}).
+			Where("status = ? AND expires_at > ?", models.SwapStatusPending, time.Now()).
+			Where("offered_assignment_id IN ? OR requested_assignment_id IN ?", assignmentIDs, assignmentIDs).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return ErrSwapConflict
+		}
+
+		swap.Status = models.SwapStatusPending
+		if err := tx.Create(swap).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: swap.HouseholdID,
+			ActorID:     &swap.RequesterID,
+			Action:      models.AuditActionSwapRequested,
+			EntityType:  models.AuditEntityChoreSwap,
+			EntityID:    swap.ID,
+		}, nil, swapSnapshot(*swap))
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notifySwapParties(models.NotificationActionSwapRequested, swap.RequesterID, *swap)
+}
+
+// swappableAssignment loads an open assignment and checks who holds it.
+func swappableAssignment(tx *gorm.DB, assignmentID uuid.UUID, householdID uuid.UUID, accountID uuid.UUID) (models.AccountChore, error) {
+	var assignment models.AccountChore
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND household_id = ?", assignmentID, householdID).
+		First(&assignment).Error
+	if err == gorm.ErrRecordNotFound {
+		return assignment, ErrInvalidSwap
+	}
+	if err != nil {
+		return assignment, err
+	}
+
+	if assignment.AccountID != accountID {
+		return assignment, ErrInvalidSwap
+	}
+	for _, status := range swappableStatuses {
+		if assignment.Status == status {
+			return assignment, nil
+		}
+	}
+	return assignment, ErrInvalidSwap
+}
+
+func (s *dbService) GetChoreSwaps(householdID uuid.UUID, accountID uuid.UUID, status models.SwapStatus) ([]models.ChoreSwapResponse, error) {
+	query := s.db.Preload("Requester").
+		Preload("Recipient").
+		Preload("OfferedAssignment.Chore").
+		Preload("RequestedAssignment.Chore").
+		Where("household_id = ? AND (requester_id = ? OR recipient_id = ?)", householdID, accountID, accountID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var swaps []models.ChoreSwap
+	if err := query.Order("created_at DESC").Find(&swaps).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]models.ChoreSwapResponse, len(swaps))
+	for i, swap := range swaps {
+		response[i] = swapResponse(swap)
+	}
+	return response, nil
+}
+
+// AcceptChoreSwap exchanges the assignments' holders. Each assignment takes
+// the rotation slot of its new holder when they are in that chore's rotation,
+// so rebalancing after someone leaves still sees who does what.
+func (s *dbService) AcceptChoreSwap(swapID uuid.UUID, accountID uuid.UUID) (models.ChoreSwapResponse, error) {
+	swap, err := s.answerChoreSwap(swapID, accountID, models.SwapStatusAccepted, func(tx *gorm.DB, swap *models.ChoreSwap) error {
+		offered, err := swappableAssignment(tx, swap.OfferedAssignmentID, swap.HouseholdID, swap.RequesterID)
+		if err != nil {
+			return err
+		}
+		if err := reassignSwappedAssignment(tx, swap, offered, swap.RecipientID); err != nil {
+			return err
+		}
+
+		if swap.RequestedAssignmentID == nil {
+			return nil
+		}
+		requested, err := swappableAssignment(tx, *swap.RequestedAssignmentID, swap.HouseholdID, swap.RecipientID)
+		if err != nil {
+			return err
+		}
+		return reassignSwappedAssignment(tx, swap, requested, swap.RequesterID)
+	})
+	if err != nil {
+		return models.ChoreSwapResponse{}, err
+	}
+
+	if err := s.notifySwapParties(models.NotificationActionSwapAccepted, accountID, swap); err != nil {
+		return models.ChoreSwapResponse{}, err
+	}
+	return s.getChoreSwap(swap.ID)
+}
+
+func reassignSwappedAssignment(tx *gorm.DB, swap *models.ChoreSwap, assignment models.AccountChore, accountID uuid.UUID) error {
+	before := assignmentSnapshot(assignment)
+
+	var rotation models.ChoreRotation
+	err := tx.Where("chore_id = ? AND account_id = ?", assignment.ChoreID, accountID).First(&rotation).Error
+	if err == nil {
+		assignment.RotationOrder = rotation.RotationOrder
+	} else if err != gorm.ErrRecordNotFound {
+		return err
+	}
+	assignment.AccountID = accountID
+
+	if err := tx.Model(&assignment).Updates(map[string]interface{}{
+		"account_id":     assignment.AccountID,
+		"rotation_order": assignment.RotationOrder,
+	}).Error; err != nil {
+		return err
+	}
+
+	actorID := swap.RecipientID
+	return recordAudit(tx, models.AuditLog{
+		HouseholdID: swap.HouseholdID,
+		ActorID:     &actorID,
+		Action:      models.AuditActionAssignmentReassigned,
+		EntityType:  models.AuditEntityAccountChore,
+		EntityID:    assignment.ID,
+	}, before, assignmentSnapshot(assignment))
+}
+
+// swapHandoff is one assignment a swap moves from one member to another.
+type swapHandoff struct {
+	swapID       uuid.UUID
+	assignmentID uuid.UUID
+	from         uuid.UUID
+	to           uuid.UUID
+}
+
+func swapHandoffs(swap models.ChoreSwap) []swapHandoff {
+	handoffs := []swapHandoff{{swap.ID, swap.OfferedAssignmentID, swap.RequesterID, swap.RecipientID}}
+	if swap.RequestedAssignmentID != nil {
+		handoffs = append(handoffs, swapHandoff{swap.ID, *swap.RequestedAssignmentID, swap.RecipientID, swap.RequesterID})
+	}
+	return handoffs
+}
+
+// acceptedSwaps loads the accepted swaps involving the assignments, oldest
+// first, along with the latest handoff of each assignment, which says who
+// holds it now.
+func acceptedSwaps(tx *gorm.DB, assignments []models.AccountChore) ([]models.ChoreSwap, map[uuid.UUID]swapHandoff, error) {
+	if len(assignments) == 0 {
+		return nil, nil, nil
+	}
+	ids := make([]uuid.UUID, len(assignments))
+	for i, assignment := range assignments {
+		ids[i] = assignment.ID
+	}
+
+	var swaps []models.ChoreSwap
+	if err := tx.Where("status = ? AND (offered_assignment_id IN ? OR requested_assignment_id IN ?)", models.SwapStatusAccepted, ids, ids).
+		Order("responded_at").
+		Find(&swaps).Error; err != nil {
+		return nil, nil, err
+	}
+
+	latest := map[uuid.UUID]swapHandoff{}
+	for _, swap := range swaps {
+		for _, handoff := range swapHandoffs(swap) {
+			latest[handoff.assignmentID] = handoff
+		}
+	}
+	return swaps, latest, nil
+}
+
+// carryOverSwaps reapplies accepted swaps to assignments that were just
+// regenerated. planned holds the regenerated assignments; ones in latest but
+// missing from planned were cancelled or belong to another chore. A swap still
+// holds when each of its regenerated assignments falls to the member who gave
+// it away, and those go back to whoever took them. Otherwise the new schedule
+// has overtaken it, so it is cancelled and returned for both parties to be
+// told. It also returns the members handed assignments.
+func carryOverSwaps(tx *gorm.DB, swaps []models.ChoreSwap, latest map[uuid.UUID]swapHandoff, regenerated []models.AccountChore, planned map[uuid.UUID]models.AccountChore, rotations []models.ChoreRotation, actorID uuid.UUID, now time.Time) (map[uuid.UUID]bool, []models.ChoreSwap, error) {
+	inChore := map[uuid.UUID]bool{}
+	for _, assignment := range regenerated {
+		inChore[assignment.ID] = true
+	}
+
+	received := map[uuid.UUID]bool{}
+	var cancelled []models.ChoreSwap
+	for i := range swaps {
+		swap := &swaps[i]
+		var moves []swapHandoff
+		holds := true
+		for _, handoff := range swapHandoffs(*swap) {
+			// Superseded by a later swap, or another chore's assignment this
+			// schedule doesn't touch
+			if latest[handoff.assignmentID].swapID != swap.ID || !inChore[handoff.assignmentID] {
+				continue
+			}
+			assignment, ok := planned[handoff.assignmentID]
+			holder := assignment.AccountID
+			if assignment.CoveredForID != nil {
+				holder = *assignment.CoveredForID
+			}
+			if !ok || holder != handoff.from {
+				holds = false
+				break
+			}
+			moves = append(moves, handoff)
+		}
+
+		if !holds {
+			if err := cancelChoreSwap(tx, swap, actorID, now); err != nil {
+				return nil, nil, err
+			}
+			cancelled = append(cancelled, *swap)
+			continue
+		}
+		for _, move := range moves {
+			updates := map[string]interface{}{"account_id": move.to, "covered_for_id": nil}
+			for _, rotation := range rotations {
+				if rotation.AccountID == move.to {
+					updates["rotation_order"] = rotation.RotationOrder
+				}
+			}
+			if err := tx.Model(&models.AccountChore{}).Where("id = ?", move.assignmentID).Updates(updates).Error; err != nil {
+				return nil, nil, err
+			}
+			received[move.to] = true
+		}
+	}
+	return received, cancelled, nil
+}
+
+// cancelChoreSwap calls off a swap that no longer fits its assignments on
+// behalf of actorID, whose change overtook it.
+func cancelChoreSwap(tx *gorm.DB, swap *models.ChoreSwap, actorID uuid.UUID, now time.Time) error {
+	before := swapSnapshot(*swap)
+	swap.Status = models.SwapStatusCancelled
+	if swap.RespondedAt == nil {
+		swap.RespondedAt = &now
+	}
+	if err := tx.Model(swap).Updates(map[string]interface{}{
+		"status":       swap.Status,
+		"responded_at": swap.RespondedAt,
+	}).Error; err != nil {
+		return err
+	}
+
+	return recordAudit(tx, models.AuditLog{
+		HouseholdID: swap.HouseholdID,
+		ActorID:     &actorID,
+		Action:      models.AuditActionSwapCancelled,
+		EntityType:  models.AuditEntityChoreSwap,
+		EntityID:    swap.ID,
+	}, before, swapSnapshot(*swap))
+}
+
+func (s *dbService) DeclineChoreSwap(swapID uuid.UUID, accountID uuid.UUID) (models.ChoreSwapResponse, error) {
+	swap, err := s.answerChoreSwap(swapID, accountID, models.SwapStatusDeclined, nil)
+	if err != nil {
+		return models.ChoreSwapResponse{}, err
+	}
+
+	if err := s.notifySwapParties(models.NotificationActionSwapDeclined, accountID, swap); err != nil {
+		return models.ChoreSwapResponse{}, err
+	}
+	return s.getChoreSwap(swap.ID)
+}
+
+func (s *dbService) CancelChoreSwap(swapID uuid.UUID, accountID uuid.UUID) (models.ChoreSwapResponse, error) {
+	swap, err := s.answerChoreSwap(swapID, accountID, models.SwapStatusCancelled, nil)
+	if err != nil {
+		return models.ChoreSwapResponse{}, err
+	}
+
+	if err := s.notifySwapParties(models.NotificationActionSwapCancelled, accountID, swap); err != nil {
+		return models.ChoreSwapResponse{}, err
+	}
+	return s.getChoreSwap(swap.ID)
+}
+
+// answerChoreSwap moves a pending swap to its final status on behalf of the
+// party allowed to do so, running apply first. A swap past its expiry is
+// marked expired instead.
+func (s *dbService) answerChoreSwap(swapID uuid.UUID, accountID uuid.UUID, status models.SwapStatus, apply func(tx *gorm.DB, swap *models.ChoreSwap) error) (models.ChoreSwap, error) {
+	var swap models.ChoreSwap
+	expired := false
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&swap, swapID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrSwapNotFound
+			}
+			return err
+		}
+
+		if status == models.SwapStatusCancelled {
+			if swap.RequesterID != accountID {
+				return ErrSwapNotRequester
+			}
+		} else if swap.RecipientID != accountID {
+			return ErrSwapNotRecipient
+		}
+		if swap.Status != models.SwapStatusPending {
+			return ErrSwapNotPending
+		}
+
+		now := time.Now()
+		if !swap.ExpiresAt.After(now) {
+			expired = true
+			return expireChoreSwap(tx, &swap, now)
+		}
+
+		if apply != nil {
+			if err := apply(tx, &swap); err != nil {
+				return err
+			}
+		}
+
+		action := map[models.SwapStatus]models.AuditAction{
+			models.SwapStatusAccepted:  models.AuditActionSwapAccepted,
+			models.SwapStatusDeclined:  models.AuditActionSwapDeclined,
+			models.SwapStatusCancelled: models.AuditActionSwapCancelled,
+		}[status]
+
+		before := swapSnapshot(swap)
+		swap.Status = status
+		swap.RespondedAt = &now
+		if err := tx.Model(&swap).Updates(map[string]interface{}{
+			"status":       swap.Status,
+			"responded_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: swap.HouseholdID,
+			ActorID:     &accountID,
+			Action:      action,
+			EntityType:  models.AuditEntityChoreSwap,
+			EntityID:    swap.ID,
+		}, before, swapSnapshot(swap))
+	})
+	if err != nil {
+		return swap, err
+	}
+
+	if expired {
+		if err := s.notifySwapParties(models.NotificationActionSwapExpired, swap.RequesterID, swap); err != nil {
+			return swap, err
+		}
+		return swap, ErrSwapExpired
+	}
+	return swap, nil
+}
+
+func expireChoreSwap(tx *gorm.DB, swap *models.ChoreSwap, now time.Time) error {
+	before := swapSnapshot(*swap)
+	swap.Status = models.SwapStatusExpired
+	if err := tx.Model(swap).Update("status", swap.Status).Error; err != nil {
+		return err
+	}
+
+	return recordAudit(tx, models.AuditLog{
+		HouseholdID: swap.HouseholdID,
+		Action:      models.AuditActionSwapExpired,
+		EntityType:  models.AuditEntityChoreSwap,
+		EntityID:    swap.ID,
+	}, before, swapSnapshot(*swap))
+}
+
+// ExpireChoreSwaps marks pending swap requests past their expiry as expired
+// and lets both parties know. It returns how many requests expired.
+func (s *dbService) ExpireChoreSwaps(now time.Time) (int, error) {
+	var expired []models.ChoreSwap
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if locked, err := tryJobLock(tx, swapJobLockKey); err != nil || !locked {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", models.SwapStatusPending, now).
+			Find(&expired).Error; err != nil {
+			return err
+		}
+		for i := range expired {
+			if err := expireChoreSwap(tx, &expired[i], now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, swap := range expired {
+		if err := s.notifySwapParties(models.NotificationActionSwapExpired, swap.RequesterID, swap); err != nil {
+			return len(expired), err
+		}
+	}
+	return len(expired), nil
+}
+
+// notifySwapParties sends a swap notification to both the requester and the
+// recipient.
+func (s *dbService) notifySwapParties(action string, actorID uuid.UUID, swap models.ChoreSwap) error {
+	notification := &models.Notification{
+		Action:          action,
+		AccountID:       actorID,
+		SwapID:          &swap.ID,
+		AccountChoreID:  &swap.OfferedAssignmentID,
+		TargetAccountID: &swap.RecipientID,
+	}
+	return s.CreateNotification(notification, []uuid.UUID{swap.RequesterID, swap.RecipientID}, swap.HouseholdID)
+}
+
+func (s *dbService) getChoreSwap(swapID uuid.UUID) (models.ChoreSwapResponse, error) {
+	var swap models.ChoreSwap
+	if err := s.db.Preload("Requester").
+		Preload("Recipient").
+		Preload("OfferedAssignment.Chore").
+		Preload("RequestedAssignment.Chore").
+		First(&swap, swapID).Error; err != nil {
+		return models.ChoreSwapResponse{}, err
+	}
+	return swapResponse(swap), nil
+}
+
+func swapResponse(swap models.ChoreSwap) models.ChoreSwapResponse {
+	response := models.ChoreSwapResponse{
+		ID:          swap.ID,
+		HouseholdID: swap.HouseholdID,
+		Requester:   models.ActorInfo{ID: swap.Requester.ID, Name: swap.Requester.Name},
+		Recipient:   models.ActorInfo{ID: swap.Recipient.ID, Name: swap.Recipient.Name},
+		Offered: models.ChoreInfo{
+			ChoreID:        swap.OfferedAssignment.ChoreID,
+			AccountChoreID: swap.OfferedAssignment.ID,
+			Title:          swap.OfferedAssignment.Chore.Title,
+			DueDate:        swap.OfferedAssignment.DueDate,
+		},
+		Message:     swap.Message,
+		Status:      swap.Status,
+		ExpiresAt:   swap.ExpiresAt,
+		RespondedAt: swap.RespondedAt,
+		CreatedAt:   swap.CreatedAt,
+	}
+	if swap.RequestedAssignment != nil {
+		response.Requested = &models.ChoreInfo{
+			ChoreID:        swap.RequestedAssignment.ChoreID,
+			AccountChoreID: swap.RequestedAssignment.ID,
+			Title:          swap.RequestedAssignment.Chore.Title,
+			DueDate:        swap.RequestedAssignment.DueDate,
+		}
+	}
+	return response
+}
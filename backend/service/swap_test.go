@@ -0,0 +1,96 @@
+package service
+
+import (
+	"chore-share/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// swapFirstTurn has alice hand her PENDING turn of a daily chore shared with
+// bob over to him.
+func swapFirstTurn(t *testing.T, s *dbService) (alice uuid.UUID, bob uuid.UUID, chore models.Chore, swap models.ChoreSwap) {
+	t.Helper()
+	alice = createTestAccount(t, s, "Alice")
+	bob = createTestAccount(t, s, "Bob")
+	householdID := createTestHousehold(t, s, alice, bob)
+	chore, assignment := createTestChore(t, s, models.Chore{
+		HouseholdID: householdID,
+		Type:        models.ChoreTypeRecurring,
+		Recurrence:  "FREQ=DAILY",
+	}, alice, bob)
+	if assignment.AccountID != alice {
+		t.Fatalf("first turn is %s's, want alice's", assignment.AccountID)
+	}
+
+	swap = models.ChoreSwap{
+		HouseholdID:         householdID,
+		RequesterID:         alice,
+		RecipientID:         bob,
+		OfferedAssignmentID: assignment.ID,
+		ExpiresAt:           time.Now().Add(time.Hour),
+	}
+	if err := s.CreateChoreSwap(&swap); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AcceptChoreSwap(swap.ID, bob); err != nil {
+		t.Fatal(err)
+	}
+	return alice, bob, chore, swap
+}
+
+func TestRegenerationKeepsAcceptedSwap(t *testing.T) {
+	s, _ := newTestService(t)
+	alice, bob, chore, swap := swapFirstTurn(t, s)
+
+	// Same rotation, so alice's turn is still hers to give away
+	if _, err := s.UpdateChore(chore.ID, ChoreUpdate{Assignees: []uuid.UUID{alice, bob}}, alice); err != nil {
+		t.Fatal(err)
+	}
+
+	var assignment models.AccountChore
+	if err := s.db.First(&assignment, swap.OfferedAssignmentID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assignment.AccountID != bob {
+		t.Errorf("swapped turn went back to %s, want bob", assignment.AccountID)
+	}
+	if err := s.db.First(&swap, swap.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if swap.Status != models.SwapStatusAccepted {
+		t.Errorf("swap is %s, want %s", swap.Status, models.SwapStatusAccepted)
+	}
+}
+
+func TestRegenerationCancelsOvertakenSwap(t *testing.T) {
+	s, _ := newTestService(t)
+	alice, bob, chore, swap := swapFirstTurn(t, s)
+
+	// Alice leaves the rotation, so there is no turn of hers left to swap
+	if _, err := s.UpdateChore(chore.ID, ChoreUpdate{Assignees: []uuid.UUID{bob}}, bob); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.db.First(&swap, swap.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if swap.Status != models.SwapStatusCancelled {
+		t.Errorf("swap is %s, want %s", swap.Status, models.SwapStatusCancelled)
+	}
+
+	for _, accountID := range []uuid.UUID{alice, bob} {
+		var count int64
+		if err := s.db.Model(&models.AccountNotification{}).
+			Joins("JOIN notifications ON notifications.id = account_notifications.notification_id").
+			Where("notifications.swap_id = ? AND notifications.action = ? AND account_notifications.account_id = ?",
+				swap.ID, models.NotificationActionSwapCancelled, accountID).
+			Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Errorf("%s got %d SWAP_CANCELLED notifications, want 1", accountID, count)
+		}
+	}
+}
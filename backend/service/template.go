@@ -0,0 +1,276 @@
+package service
+
+import (
+	"chore-share/models"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("chore template not found")
+	ErrInvalidTemplate  = errors.New("invalid chore template")
+	ErrEndDateRequired  = errors.New("one-time chores need an end date")
+	ErrBuiltInTemplate  = errors.New("starter templates cannot be deleted")
+)
+
+// TemplateInstance holds what a template leaves open when a chore is created
+// from it.
+type TemplateInstance struct {
+	Assignees []uuid.UUID
+	EndDate   time.Time
+	StartDate *time.Time
+	Strategy  models.AssignmentStrategy
+}
+
+// starterPack is offered to every household. Its IDs are derived from the
+// entry's key so they stay the same across restarts.
+var starterPack = []models.ChoreTemplate{
+	starterTemplate("dishes", "Do the dishes", "Wash, dry and put away the day's dishes.", "KITCHEN", models.FrequencyTypeDaily, nil, 2, 20),
+	starterTemplate("kitchen", "Clean the kitchen", "Wipe the counters and stovetop, clean the sink and mop the floor.", "KITCHEN", models.FrequencyTypeWeekly, []int{6}, 5, 45),
+	starterTemplate("fridge", "Clear out the fridge", "Throw out anything expired and wipe the shelves.", "KITCHEN", models.FrequencyTypeWeekly, []int{4}, 2, 15),
+	starterTemplate("bathroom", "Clean the bathroom", "Scrub the toilet, sink and shower, and replace the towels.", "BATHROOM", models.FrequencyTypeWeekly, []int{7}, 5, 40),
+	starterTemplate("bathroom-restock", "Restock the bathroom", "Refill toilet paper, soap and other supplies.", "BATHROOM", models.FrequencyTypeWeekly, []int{3}, 1, 10),
+	starterTemplate("trash", "Take out the trash", "Empty every bin and take the bags out for collection.", "TRASH", models.FrequencyTypeWeekly, []int{2, 5}, 1, 10),
+	starterTemplate("recycling", "Take out the recycling", "Sort the recycling and put it out for collection.", "TRASH", models.FrequencyTypeWeekly, []int{4}, 1, 10),
+	starterTemplate("vacuum", "Vacuum the common areas", "Vacuum the living room, hallway and stairs.", "GENERAL", models.FrequencyTypeWeekly, []int{6}, 3, 30),
+	starterTemplate("plants", "Water the plants", "", "GENERAL", models.FrequencyTypeWeekly, []int{3, 7}, 1, 10),
+}
+
+func starterTemplate(key string, title string, description string, category string, frequency models.FrequencyType, schedule []int, points int, minutes int) models.ChoreTemplate {
+	template := models.ChoreTemplate{
+		ID:               uuid.NewSHA1(uuid.NameSpaceURL, []byte("chore-share:starter-template:"+key)),
+		Title:            title,
+		Description:      description,
+		Category:         category,
+		Type:             models.ChoreTypeRecurring,
+		FrequencyType:    &frequency,
+		Points:           points,
+		EstimatedMinutes: minutes,
+	}
+	template.SetScheduleDays(schedule)
+	return template
+}
+
+func starterTemplateByID(templateID uuid.UUID) (models.ChoreTemplate, bool) {
+	for _, template := range starterPack {
+		if template.ID == templateID {
+			return template, true
+		}
+	}
+	return models.ChoreTemplate{}, false
+}
+
+func (s *dbService) CreateChoreTemplate(template *models.ChoreTemplate) error {
+	template.Title = strings.TrimSpace(template.Title)
+	template.Category = strings.ToUpper(strings.TrimSpace(template.Category))
+	if template.Title == "" {
+		return ErrInvalidName
+	}
+	if template.Points < 0 {
+		return ErrInvalidChorePoints
+	}
+	if template.EstimatedMinutes < 0 {
+		return ErrInvalidEstimate
+	}
+	if err := validateTemplateSchedule(template); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(template).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: template.HouseholdID,
+			ActorID:     &template.CreatedByID,
+			Action:      models.AuditActionTemplateCreated,
+			EntityType:  models.AuditEntityChoreTemplate,
+			EntityID:    template.ID,
+		}, nil, templateSnapshot(*template))
+	})
+}
+
+// validateTemplateSchedule checks the template would produce a valid chore.
+func validateTemplateSchedule(template *models.ChoreTemplate) error {
+	switch template.Type {
+	case models.ChoreTypeOneTime:
+		template.FrequencyType = nil
+		template.Schedule = ""
+		template.Recurrence = ""
+		return nil
+	case models.ChoreTypeRecurring:
+	default:
+		return ErrInvalidTemplate
+	}
+
+	days := template.ScheduleDays()
+	schedule := make([]models.ChoreSchedule, len(days))
+	for i, day := range days {
+		if day < 1 || day > 7 {
+			return ErrInvalidTemplate
+		}
+		schedule[i] = models.ChoreSchedule{DayOfWeek: day}
+	}
+	template.SetScheduleDays(days)
+
+	chore := models.Chore{FrequencyType: template.FrequencyType, Recurrence: template.Recurrence}
+	return prepareRecurrence(&chore, schedule)
+}
+
+// GetChoreTemplates lists the templates usable in the household: the starter
+// pack, the household's own templates and the shared templates of the
+// account's other households.
+func (s *dbService) GetChoreTemplates(householdID uuid.UUID, accountID uuid.UUID) ([]models.ChoreTemplateResponse, error) {
+	var households []uuid.UUID
+	if err := s.db.Model(&models.AccountHousehold{}).
+		Where("account_id = ?", accountID).
+		Pluck("household_id", &households).Error; err != nil {
+		return nil, err
+	}
+
+	var templates []models.ChoreTemplate
+	if err := s.db.Preload("Household").
+		Joins("JOIN households ON households.id = chore_templates.household_id AND households.deleted_at IS NULL").
+		Where("chore_templates.household_id = ? OR (chore_templates.shared = ? AND chore_templates.household_id IN ?)",
+			householdID, true, households).
+		Order("chore_templates.category, chore_templates.title").
+		Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]models.ChoreTemplateResponse, 0, len(starterPack)+len(templates))
+	for _, template := range starterPack {
+		response = append(response, templateResponse(template, true))
+	}
+	for _, template := range templates {
+		response = append(response, templateResponse(template, false))
+	}
+	return response, nil
+}
+
+// usableTemplate loads a template the account may use in the household.
+func usableTemplate(tx *gorm.DB, templateID uuid.UUID, householdID uuid.UUID, accountID uuid.UUID) (models.ChoreTemplate, error) {
+	if template, ok := starterTemplateByID(templateID); ok {
+		return template, nil
+	}
+
+	var template models.ChoreTemplate
+	if err := tx.First(&template, templateID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return template, ErrTemplateNotFound
+		}
+		return template, err
+	}
+	if template.HouseholdID == householdID {
+		return template, nil
+	}
+	if !template.Shared {
+		return template, ErrTemplateNotFound
+	}
+
+	var count int64
+	if err := tx.Model(&models.AccountHousehold{}).
+		Joins("JOIN households ON households.id = account_households.household_id AND households.deleted_at IS NULL").
+		Where("account_households.account_id = ? AND account_households.household_id = ?", accountID, template.HouseholdID).
+		Count(&count).Error; err != nil {
+		return template, err
+	}
+	if count == 0 {
+		return template, ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+// InstantiateChoreTemplate creates a chore in the household from a template.
+func (s *dbService) InstantiateChoreTemplate(templateID uuid.UUID, householdID uuid.UUID, instance TemplateInstance, actorID uuid.UUID) (*models.Chore, error) {
+	template, err := usableTemplate(s.db, templateID, householdID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if template.Type == models.ChoreTypeOneTime && instance.EndDate.IsZero() {
+		return nil, ErrEndDateRequired
+	}
+
+	chore := &models.Chore{
+		Title:            template.Title,
+		Description:      template.Description,
+		HouseholdID:      householdID,
+		Type:             template.Type,
+		Points:           template.Points,
+		EstimatedMinutes: template.EstimatedMinutes,
+		EndDate:          instance.EndDate,
+		Strategy:         instance.Strategy,
+	}
+
+	var schedule []models.ChoreSchedule
+	if template.Type == models.ChoreTypeRecurring {
+		chore.FrequencyType = template.FrequencyType
+		chore.Recurrence = template.Recurrence
+		chore.RecurrenceStart = instance.StartDate
+		for _, day := range template.ScheduleDays() {
+			schedule = append(schedule, models.ChoreSchedule{DayOfWeek: day})
+		}
+	}
+
+	if err := s.CreateChore(chore, instance.Assignees, schedule, actorID); err != nil {
+		return nil, err
+	}
+	return chore, nil
+}
+
+func (s *dbService) DeleteChoreTemplate(templateID uuid.UUID, householdID uuid.UUID, actorID uuid.UUID) error {
+	if _, ok := starterTemplateByID(templateID); ok {
+		return ErrBuiltInTemplate
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var template models.ChoreTemplate
+		if err := tx.Where("id = ? AND household_id = ?", templateID, householdID).
+			First(&template).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrTemplateNotFound
+			}
+			return err
+		}
+
+		if err := tx.Delete(&template).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, models.AuditLog{
+			HouseholdID: householdID,
+			ActorID:     &actorID,
+			Action:      models.AuditActionTemplateDeleted,
+			EntityType:  models.AuditEntityChoreTemplate,
+			EntityID:    template.ID,
+		}, templateSnapshot(template), nil)
+	})
+}
+
+func templateResponse(template models.ChoreTemplate, builtIn bool) models.ChoreTemplateResponse {
+	response := models.ChoreTemplateResponse{
+		ID:               template.ID,
+		Title:            template.Title,
+		Description:      template.Description,
+		Category:         template.Category,
+		Type:             template.Type,
+		FrequencyType:    template.FrequencyType,
+		Schedule:         template.ScheduleDays(),
+		Recurrence:       template.Recurrence,
+		Points:           template.Points,
+		EstimatedMinutes: template.EstimatedMinutes,
+		Shared:           template.Shared,
+		BuiltIn:          builtIn,
+	}
+	if !builtIn {
+		response.HouseholdID = &template.HouseholdID
+		response.HouseholdName = template.Household.Name
+		response.CreatedAt = &template.CreatedAt
+	}
+	return response
+}
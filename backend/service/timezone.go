@@ -0,0 +1,39 @@
+package service
+
+import (
+	"chore-share/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func validTimezone(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// householdLocation returns the zone the household's day boundaries are
+// computed in, falling back to UTC for households without a usable one.
+func householdLocation(db *gorm.DB, householdID uuid.UUID) (*time.Location, error) {
+	var household models.Household
+	if err := db.Unscoped().Select("timezone").First(&household, householdID).Error; err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(household.Timezone)
+	if err != nil || household.Timezone == "" {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// monthBounds returns the first instant of t's month in loc and the first
+// instant of the following month.
+func monthBounds(t time.Time, loc *time.Location) (time.Time, time.Time) {
+	t = t.In(loc)
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 1, 0)
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStore(t *testing.T) (*LocalStore, *time.Time) {
+	t.Helper()
+	store, err := NewLocalStore(filepath.Join(t.TempDir(), "blobs"), "https://api.example.com/files/", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+// serve requests a signed URL the way the /files mount would see it.
+func serve(store *LocalStore, signedURL string) *httptest.ResponseRecorder {
+	target, _ := url.Parse(signedURL)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.URL.Path = strings.TrimPrefix(target.Path, "/files")
+	r.URL.RawQuery = target.RawQuery
+	w := httptest.NewRecorder()
+	store.ServeHTTP(w, r)
+	return w
+}
+
+func TestLocalStoreSignedURL(t *testing.T) {
+	store, now := newTestLocalStore(t)
+	if err := store.Put(context.Background(), "photos/a.jpg", "image/jpeg", []byte("photo")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(context.Background(), "photos/b.jpg", "image/jpeg", []byte("other")); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := store.SignedURL("photos/a.jpg", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(signed, "https://api.example.com/files/photos/a.jpg?") {
+		t.Fatalf("SignedURL = %s", signed)
+	}
+
+	w := serve(store, signed)
+	if body, _ := io.ReadAll(w.Body); w.Code != http.StatusOK || string(body) != "photo" {
+		t.Fatalf("valid link: status %d body %q", w.Code, body)
+	}
+
+	target, _ := url.Parse(signed)
+	query := target.Query()
+	tampered := map[string]string{
+		"other key":         strings.Replace(signed, "photos/a.jpg", "photos/b.jpg", 1),
+		"later expiry":      strings.Replace(signed, "expires="+query.Get("expires"), "expires="+strconv.FormatInt(now.Add(time.Hour).Unix(), 10), 1),
+		"other signature":   strings.Replace(signed, "signature="+query.Get("signature"), "signature=AAAA"+query.Get("signature")[4:], 1),
+		"missing signature": strings.Split(signed, "&signature=")[0],
+	}
+	for name, link := range tampered {
+		if w := serve(store, link); w.Code != http.StatusForbidden {
+			t.Errorf("%s: status %d, want %d", name, w.Code, http.StatusForbidden)
+		}
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if w := serve(store, signed); w.Code != http.StatusForbidden {
+		t.Errorf("expired link: status %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestLocalStoreRejectsTraversal(t *testing.T) {
+	store, now := newTestLocalStore(t)
+	outside := filepath.Join(filepath.Dir(store.root), "outside.txt")
+	if err := os.WriteFile(outside, []byte("private"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../outside.txt", "photos/../../outside.txt", "..", "/etc/passwd", "photos//a.jpg", ""} {
+		if err := store.Put(context.Background(), key, "text/plain", []byte("x")); err != ErrInvalidKey {
+			t.Errorf("Put(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+		if err := store.Delete(context.Background(), key); err != ErrInvalidKey {
+			t.Errorf("Delete(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+		if _, err := store.SignedURL(key, time.Minute); err != ErrInvalidKey {
+			t.Errorf("SignedURL(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+	}
+
+	// Even a correctly signed traversal key must not reach outside root
+	expires := strconv.FormatInt(now.Add(time.Minute).Unix(), 10)
+	key := "../outside.txt"
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.URL.Path = "/" + key
+	r.URL.RawQuery = url.Values{"expires": {expires}, "signature": {store.sign(key, expires)}}.Encode()
+	w := httptest.NewRecorder()
+	store.ServeHTTP(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("signed traversal: status %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if data, _ := os.ReadFile(outside); string(data) != "private" {
+		t.Errorf("file outside root was changed to %q", data)
+	}
+}